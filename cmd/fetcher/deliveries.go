@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-weekly-log/internal/database"
+	"github-weekly-log/internal/email"
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"os"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+)
+
+// 送信に失敗した送信先があることを表すエラー（`fetcher send` や再実行で未送信の送信先にだけ再送できる）
+var errNotDelivered = errors.New("未送信の送信先があります")
+
+// 週間レポートの送信済みの記録（D1）
+type deliveryStore struct {
+	client     *cloudflare.Client
+	accountID  string
+	databaseID string
+}
+
+// 送信済みの記録を生成（D1 を使わない場合は nil で、記録せずに全員へ送信する）
+func newDeliveryStore(client *cloudflare.Client, accountID, databaseID string) *deliveryStore {
+	if client == nil {
+		return nil
+	}
+	return &deliveryStore{client: client, accountID: accountID, databaseID: databaseID}
+}
+
+// 指定した週に未送信の送信先があるか
+func (s *deliveryStore) hasPending(username string, startDate time.Time, recipients []email.Recipient) (bool, error) {
+	if s == nil {
+		return true, nil
+	}
+	delivered, err := database.LoadDeliveredKeys(context.Background(), s.client, s.accountID, s.databaseID, username, startDate)
+	if err != nil {
+		return false, err
+	}
+	week := github.WeeklyComparison{CurrentWeek: &github.WeeklyStats{Username: username, StartDate: startDate}}
+	for _, recipient := range recipients {
+		if !delivered[email.DeliveryKey(week, recipient)] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// 送信済みの送信先を除いた送信先
+func (s *deliveryStore) pending(comparison github.WeeklyComparison, recipients []email.Recipient) ([]email.Recipient, error) {
+	if s == nil {
+		return recipients, nil
+	}
+	current := comparison.CurrentWeek
+	delivered, err := database.LoadDeliveredKeys(context.Background(), s.client, s.accountID, s.databaseID, current.Username, current.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []email.Recipient
+	for _, recipient := range recipients {
+		if delivered[email.DeliveryKey(comparison, recipient)] {
+			i18n.Println("console.delivery_skipped", recipient.Address)
+			continue
+		}
+		pending = append(pending, recipient)
+	}
+	return pending, nil
+}
+
+// 冪等キーが送信済みとして記録されているか
+func (s *deliveryStore) delivered(username string, startDate time.Time, key string) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+	delivered, err := database.LoadDeliveredKeys(context.Background(), s.client, s.accountID, s.databaseID, username, startDate)
+	if err != nil {
+		return false, err
+	}
+	return delivered[key], nil
+}
+
+// 送信できた送信先を送信済みとして記録（username はチームダイジェストの場合は email.TeamDeliveryName）
+func (s *deliveryStore) record(username string, startDate time.Time, results []email.SendResult) error {
+	if s == nil {
+		return nil
+	}
+	var deliveries []database.Delivery
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		deliveries = append(deliveries, database.Delivery{
+			Username:       username,
+			StartDate:      startDate,
+			Recipient:      result.Recipient.Address,
+			IdempotencyKey: result.Key,
+			MessageID:      result.ID,
+		})
+	}
+	return database.RecordDeliveries(context.Background(), s.client, s.accountID, s.databaseID, deliveries)
+}
+
+// 週間レポートを未送信の送信先にだけ送信し、送信できた送信先を記録する
+// 失敗した送信先がある場合は errNotDelivered を含むエラーを返す
+func deliverWeeklyReports(sender email.Sender, store *deliveryStore, comparison github.WeeklyComparison, recipients []email.Recipient, emailDomain string) error {
+	pending, err := store.pending(comparison, recipients)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		current := comparison.CurrentWeek
+		i18n.Println("console.week_delivered", current.StartDate.Format("2006-01-02"), current.EndDate.Format("2006-01-02"))
+		return nil
+	}
+
+	charts, err := email.RenderCharts(comparison)
+	if err != nil {
+		return err
+	}
+	results := email.SendWeeklyReports(sender, comparison, charts, pending, emailDomain)
+	current := comparison.CurrentWeek
+	recordDeliveries(store, current.Username, current.StartDate, results)
+	return printSendResults(results)
+}
+
+// 送信結果を記録する
+// 送信は完了しているため、記録に失敗しても警告を表示して続行する
+// 記録できなかった送信先には再実行時にもう一度送信する（Resend は 24時間以内なら冪等キーで重複を防ぐが、
+// それ以降や SMTP・ファイルでは二重に届く）
+func recordDeliveries(store *deliveryStore, username string, startDate time.Time, results []email.SendResult) {
+	if err := store.record(username, startDate, results); err != nil {
+		i18n.Println("console.record_failed", err)
+	}
+}
+
+// 送信先ごとの送信結果を表示し、失敗した送信先のエラーをまとめて返す
+func printSendResults(results []email.SendResult) error {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Printf("  ❌ %s: %v\n", result.Recipient.Address, result.Err)
+			errs = append(errs, fmt.Errorf("%s への送信に失敗しました: %w", result.Recipient.Address, result.Err))
+			continue
+		}
+		fmt.Printf("  ✅ %s (%s)\n", result.Recipient.Address, result.ID)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w\n%w", errNotDelivered, errors.Join(errs...))
+}
+
+// 送信に失敗した場合は panic せずにエラーを表示して終了する
+// hint は未送信の送信先に再送する方法のメッセージキー（空の場合は表示しない）
+// 送信の失敗でない場合は何もしない
+func exitOnSendError(err error, hint string) {
+	if !errors.Is(err, errNotDelivered) {
+		return
+	}
+	fmt.Println(err)
+	if hint != "" {
+		i18n.Println(hint)
+	}
+	os.Exit(1)
+}
+
+// D1 に保存済みの場合に表示する再送方法（D1 を使わない場合は表示しない）
+func sendHint(cfClient *cloudflare.Client, hint string) string {
+	if cfClient == nil {
+		return ""
+	}
+	return hint
+}
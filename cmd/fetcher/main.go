@@ -7,53 +7,126 @@ import (
 	"github-weekly-log/internal/document"
 	"github-weekly-log/internal/email"
 	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cloudflare/cloudflare-go/v6"
 	"github.com/joho/godotenv"
 )
 
 func main() {
 	_ = godotenv.Load()
 
+	if err := loadTemplates(); err != nil {
+		panic(err)
+	}
+
+	reportLang, err := loadLanguages()
+	if err != nil {
+		panic(err)
+	}
+
 	emailOnly := false
 
 	if len(os.Args) > 1 {
 		switch os.Args[1] {
 		case "email-test":
-			err := email.TestWeeklyMailSend()
+			sender, err := loadEmailSender()
+			if err != nil {
+				panic(err)
+			}
+			err = email.TestWeeklyMailSend(sender)
 			if err != nil {
 				panic(err)
 			}
 			return
 		case "email-only":
 			emailOnly = true
+		case "repos":
+			if err := runReposCommand(os.Args[2:]); err != nil {
+				panic(err)
+			}
+			return
+		case "preview":
+			if err := runPreviewCommand(os.Args[2:], reportLang); err != nil {
+				panic(err)
+			}
+			return
+		case "send":
+			if err := runSendCommand(os.Args[2:], reportLang); err != nil {
+				exitOnSendError(err, "")
+				panic(err)
+			}
+			return
 		}
 	}
 
 	GITHUB_TOKEN := os.Getenv("GITHUB_TOKEN")
 	GITHUB_USER := os.Getenv("GITHUB_USER")
-	EMAIL_API_KEY := os.Getenv("RESEND_API_KEY")
 	EMAIL_DOMAIN := os.Getenv("RESEND_EMAIL_DOMAIN")
-	EMAIL_TO := os.Getenv("RESEND_EMAIL_TO")
 	D1_API_TOKEN := os.Getenv("D1_API_TOKEN")
 	D1_ACCOUNT_ID := os.Getenv("D1_ACCOUNT_ID")
 	APP_ENV := os.Getenv("APP_ENV")
+	GITHUB_USERS := os.Getenv("GITHUB_USERS")
 	var D1_DATABASE_ID string
 
 	if !emailOnly {
 		if APP_ENV == "development" {
-			fmt.Println("開発環境で実行中です。開発DBに保存されます。")
-			D1_DATABASE_ID = os.Getenv("D1_DATABASE_ID_DEV")
+			i18n.Println("console.development")
 		} else {
-			fmt.Println("本番環境で実行中です。本番DBに保存されます。")
-			D1_DATABASE_ID = os.Getenv("D1_DATABASE_ID")
+			i18n.Println("console.production")
 		}
+		D1_DATABASE_ID = loadD1DatabaseID()
 	} else {
-		fmt.Println("email-only モード: DB保存をスキップします。")
+		i18n.Println("console.email_only")
 	}
 
-	client := github.NewClient(GITHUB_TOKEN)
+	privacy, err := loadPrivacyConfig()
+	if err != nil {
+		panic(err)
+	}
+
+	sender, err := loadEmailSender()
+	if err != nil {
+		panic(err)
+	}
+
+	recipients, err := loadRecipients(reportLang)
+	if err != nil {
+		panic(err)
+	}
+
+	client, err := loadGitHubClient(GITHUB_TOKEN)
+	if err != nil {
+		panic(err)
+	}
+
+	var cfClient *cloudflare.Client
+	if !emailOnly {
+		cfClient = database.InitD1(D1_API_TOKEN, D1_ACCOUNT_ID)
+		// マイグレーション 0001 で username が空になった既存の行を GITHUB_USER のデータとして引き継ぐ
+		if err := database.ClaimLegacyWeeklyStats(context.Background(), cfClient, D1_ACCOUNT_ID, D1_DATABASE_ID, GITHUB_USER); err != nil {
+			panic(err)
+		}
+	}
+
+	// GITHUB_USERS が設定されている場合はチームモードで実行
+	if GITHUB_USERS != "" {
+		cfg := parseTeamConfig(GITHUB_USERS, os.Getenv("TEAM_MEMBER_EMAILS"), os.Getenv("TEAM_LEAD_EMAIL"))
+		cfg.Language = reportLang
+		err := runTeamReport(client, cfg, privacy, cfClient, D1_ACCOUNT_ID, D1_DATABASE_ID, sender, EMAIL_DOMAIN)
+		if err != nil {
+			// send サブコマンドは個人レポートのみ対応のため、チームモードは再実行を案内する
+			exitOnSendError(err, sendHint(cfClient, "console.team_send_hint"))
+			panic(err)
+		}
+		return
+	}
 
 	fmt.Println("Start scanning")
 	comparison, err := client.FetchWeeklyCommitsWithComparison(context.Background(), GITHUB_USER)
@@ -62,76 +135,426 @@ func main() {
 	}
 	fmt.Println("Finished scanning")
 
+	if cfClient != nil {
+		// D1の履歴から継続記録などを計算
+		applyHistory(client, cfClient, D1_ACCOUNT_ID, D1_DATABASE_ID, privacy.D1, comparison)
+	}
+
 	// 結果表示
-	printWeeklyComparison(comparison)
+	printWeeklyComparison(privacy.Console.Apply(comparison))
 
 	// JSONファイル生成
-	err = document.GenerateJSONData(comparison)
+	jsonData := privacy.JSON.Apply(comparison)
+	err = document.GenerateJSONData(jsonData)
+	if err != nil {
+		panic(err)
+	}
+	err = document.GenerateMarkdownData(jsonData, reportLang)
 	if err != nil {
 		panic(err)
 	}
 	fmt.Println("Finished generating")
 
-	if !emailOnly {
+	if cfClient != nil {
 		// D1に保存
-		cfClient := database.InitD1(D1_API_TOKEN, D1_ACCOUNT_ID)
-
 		fmt.Println("Save to D1")
-		err = database.SaveWeeklyStatsToD1WithTransaction(context.Background(), cfClient, D1_ACCOUNT_ID, D1_DATABASE_ID, comparison.CurrentWeek)
+		err = database.SaveWeeklyStatsToD1WithTransaction(context.Background(), cfClient, D1_ACCOUNT_ID, D1_DATABASE_ID, privacy.D1.Apply(comparison))
 		if err != nil {
 			panic(err)
 		}
 	}
 
-	fmt.Println("Load HTML template")
-	// HTMLテンプレート読み込み
-	htmlContent, err := email.LoadTemplate(*comparison)
+	fmt.Println("Send weekly report email")
+	// メール送信（送信先ごとに描画し、送信済みの送信先はスキップする）
+	deliveries := newDeliveryStore(cfClient, D1_ACCOUNT_ID, D1_DATABASE_ID)
+	err = deliverWeeklyReports(sender, deliveries, *privacy.Email.Apply(comparison), recipients, EMAIL_DOMAIN)
 	if err != nil {
+		exitOnSendError(err, sendHint(cfClient, "console.send_hint"))
 		panic(err)
 	}
 
-	fmt.Println("Send weekly report email")
-	// メール送信
-	err = email.SendWeeklyReport(EMAIL_API_KEY, htmlContent, "", EMAIL_DOMAIN, EMAIL_TO)
+}
+
+// APP_ENV に応じた D1 のデータベースID（development の場合は開発DB）
+func loadD1DatabaseID() string {
+	if os.Getenv("APP_ENV") == "development" {
+		return os.Getenv("D1_DATABASE_ID_DEV")
+	}
+	return os.Getenv("D1_DATABASE_ID")
+}
+
+// D1に保存済みの履歴を読み込み、履歴が必要な指標を計算する
+// 履歴は PRIVACY_D1 で名前を変換して保存されているため、元の名前に戻してから今週のデータと照合する
+// 履歴が取得できない場合は今週・先週のデータのみで計算した値のまま続行する
+func applyHistory(client *github.Client, cfClient *cloudflare.Client, accountID, databaseID string, d1Privacy github.PrivacyPolicy, comparison *github.WeeklyComparison) {
+	current := comparison.CurrentWeek
+	history, err := database.LoadWeeklyHistory(context.Background(), cfClient, accountID, databaseID, current.Username, current.StartDate, 0)
 	if err != nil {
-		panic(err)
+		i18n.Println("console.history_failed", err)
+		return
+	}
+	history = d1Privacy.UnmaskHistory(history, comparison)
+
+	comparison.Streaks = github.CalculateStreaks(history, comparison)
+	comparison.Trends = github.CalculateTrends(history, comparison)
+	comparison.LongTermHeatmap, comparison.LongTermWeeks = github.AggregateHeatmap(history, current, github.HEATMAP_WEEKS)
+	comparison.WorkLifeWarning = client.EvaluateWorkLifeWarning(history, comparison)
+	comparison.GoalSummaries = client.SummarizeGoals(history, comparison)
+}
+
+// 環境変数から集計対象のリポジトリを決めるルールを読み込む
+func loadRepoFilter() (github.RepoFilter, error) {
+	cfg := github.RepoFilterConfig{
+		Include:       os.Getenv("REPO_INCLUDE"),
+		Owners:        os.Getenv("REPO_OWNERS"),
+		Exclude:       os.Getenv("REPO_EXCLUDE"),
+		ExcludeTopics: os.Getenv("REPO_EXCLUDE_TOPICS"),
+	}
+
+	flags := map[string]*bool{
+		"REPO_EXCLUDE_FORKS":     &cfg.ExcludeForks,
+		"REPO_EXCLUDE_ARCHIVED":  &cfg.ExcludeArchived,
+		"REPO_EXCLUDE_TEMPLATES": &cfg.ExcludeTemplates,
+	}
+	for key, target := range flags {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return github.RepoFilter{}, fmt.Errorf("%s の値が不正です: %w", key, err)
+		}
+		*target = enabled
+	}
+
+	return github.ParseRepoFilter(cfg)
+}
+
+// 環境変数からメールの送信方法を読み込む
+// EMAIL_PROVIDER が smtp の場合は SMTP_* を、file の場合は EMAIL_FILE_* を、それ以外は RESEND_API_KEY を使う
+// EMAIL_RETRY_ATTEMPTS: "3"（最大試行回数）、EMAIL_RETRY_DELAY: "2s"（1回目の再試行までの待ち時間）
+func loadEmailSender() (email.Sender, error) {
+	cfg := email.Config{
+		Provider:     os.Getenv("EMAIL_PROVIDER"),
+		ResendAPIKey: os.Getenv("RESEND_API_KEY"),
+		SMTP: email.SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			Security: os.Getenv("SMTP_SECURITY"),
+		},
+		File: email.FileConfig{
+			Dir:    os.Getenv("EMAIL_FILE_DIR"),
+			Format: os.Getenv("EMAIL_FILE_FORMAT"),
+		},
+	}
+	if value := os.Getenv("SMTP_PORT"); value != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("SMTP_PORT の値が不正です: %w", err)
+		}
+		cfg.SMTP.Port = port
+	}
+	if value := os.Getenv("EMAIL_RETRY_ATTEMPTS"); value != "" {
+		attempts, err := strconv.Atoi(value)
+		if err != nil || attempts <= 0 {
+			return nil, fmt.Errorf("EMAIL_RETRY_ATTEMPTS の値が不正です: %q", value)
+		}
+		cfg.Retry.Attempts = attempts
+	}
+	if value := os.Getenv("EMAIL_RETRY_DELAY"); value != "" {
+		delay, err := time.ParseDuration(value)
+		if err != nil || delay <= 0 {
+			return nil, fmt.Errorf("EMAIL_RETRY_DELAY の値が不正です: %q", value)
+		}
+		cfg.Retry.BaseDelay = delay
+	}
+	return email.NewSender(cfg)
+}
+
+// 環境変数の設定を反映した GitHub クライアントを生成
+func loadGitHubClient(token string) (*github.Client, error) {
+	schedule, err := loadWorkSchedule()
+	if err != nil {
+		return nil, err
+	}
+
+	repoFilter, err := loadRepoFilter()
+	if err != nil {
+		return nil, err
 	}
 
+	commitFilter, err := loadCommitFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	goals, goalWeeks, err := loadGoals()
+	if err != nil {
+		return nil, err
+	}
+
+	return github.NewClientWithOptions(token, github.Options{
+		LanguageFilter: github.ParseLanguageFilter(os.Getenv("LANGUAGE_EXCLUDE")),
+		LanguageMetric: os.Getenv("LANGUAGE_METRIC"),
+		WorkSchedule:   &schedule,
+		RepoFilter:     &repoFilter,
+		CommitFilter:   &commitFilter,
+		Goals:          goals,
+		GoalWeeks:      goalWeeks,
+	}), nil
+}
+
+// 環境変数から週間レポートの送信先を読み込む
+// EMAIL_RECIPIENTS: "me@example.com; 上司 <boss@example.com>|sections=goals,repos|cc=lead@example.com"
+// 未設定の場合は RESEND_EMAIL_TO に全セクションを送信する
+// 言語を指定していない送信先には lang を使う
+func loadRecipients(lang i18n.Language) ([]email.Recipient, error) {
+	spec := os.Getenv("EMAIL_RECIPIENTS")
+	if spec == "" {
+		return []email.Recipient{{Address: os.Getenv("RESEND_EMAIL_TO"), Language: lang}}, nil
+	}
+	recipients, err := email.ParseRecipients(spec)
+	if err != nil {
+		return nil, fmt.Errorf("EMAIL_RECIPIENTS の値が不正です: %w", err)
+	}
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("EMAIL_RECIPIENTS に送信先がありません")
+	}
+	for i := range recipients {
+		if recipients[i].Language == "" {
+			recipients[i].Language = lang
+		}
+	}
+	return recipients, nil
+}
+
+// 環境変数からレポートとコンソール出力の言語を読み込み、レポートの言語を返す
+// REPORT_LANGUAGE:  "en"（メール・Markdown の既定の言語、未設定の場合は ja）
+// CONSOLE_LANGUAGE: "en"（コンソール出力・ログの言語、未設定の場合は REPORT_LANGUAGE）
+func loadLanguages() (i18n.Language, error) {
+	reportLang, err := i18n.Parse(os.Getenv("REPORT_LANGUAGE"))
+	if err != nil {
+		return "", fmt.Errorf("REPORT_LANGUAGE の値が不正です: %w", err)
+	}
+
+	consoleLang := reportLang
+	if value := os.Getenv("CONSOLE_LANGUAGE"); value != "" {
+		if consoleLang, err = i18n.Parse(value); err != nil {
+			return "", fmt.Errorf("CONSOLE_LANGUAGE の値が不正です: %w", err)
+		}
+	}
+	i18n.SetConsole(consoleLang)
+	return reportLang, nil
+}
+
+// 環境変数からテンプレートの上書き先を読み込み、全テンプレートを検証する
+// EMAIL_TEMPLATE_DIR: "./templates/dist"（未設定の場合はバイナリに埋め込んだテンプレートを使う）
+func loadTemplates() error {
+	if err := email.SetTemplateDir(os.Getenv("EMAIL_TEMPLATE_DIR")); err != nil {
+		return fmt.Errorf("EMAIL_TEMPLATE_DIR の値が不正です: %w", err)
+	}
+	if err := email.ValidateTemplates(); err != nil {
+		return fmt.Errorf("テンプレートの検証に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// 環境変数から週ごとの目標と達成率を集計する週数を読み込む
+// WEEKLY_GOALS:       "active_days:5,commits:30,merged_prs:1"
+// WEEKLY_GOALS_WEEKS: "12"
+func loadGoals() ([]github.Goal, int, error) {
+	goals, err := github.ParseGoals(os.Getenv("WEEKLY_GOALS"))
+	if err != nil {
+		return nil, 0, fmt.Errorf("WEEKLY_GOALS の値が不正です: %w", err)
+	}
+
+	weeks := 0
+	if value := os.Getenv("WEEKLY_GOALS_WEEKS"); value != "" {
+		weeks, err = strconv.Atoi(value)
+		if err != nil || weeks <= 0 {
+			return nil, 0, fmt.Errorf("WEEKLY_GOALS_WEEKS の値が不正です: %q", value)
+		}
+	}
+	return goals, weeks, nil
+}
+
+// 環境変数から集計から除外するコミットのルールを読み込む
+func loadCommitFilter() (github.CommitFilter, error) {
+	cfg := github.CommitFilterConfig{
+		BotAuthors:     os.Getenv("COMMIT_BOT_AUTHORS"),
+		ExcludeMessage: os.Getenv("COMMIT_EXCLUDE_MESSAGE"),
+		ExcludePaths:   os.Getenv("COMMIT_EXCLUDE_PATHS"),
+		IgnorePaths:    os.Getenv("COMMIT_IGNORE_PATHS"),
+	}
+	if value := os.Getenv("COMMIT_EXCLUDE_BOTS"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return github.CommitFilter{}, fmt.Errorf("COMMIT_EXCLUDE_BOTS の値が不正です: %w", err)
+		}
+		cfg.ExcludeBots = enabled
+	}
+	return github.ParseCommitFilter(cfg)
+}
+
+// 出力先ごとのプライバシー設定
+type privacyConfig struct {
+	JSON    github.PrivacyPolicy // JSON・Markdown ファイル
+	Email   github.PrivacyPolicy // メール
+	D1      github.PrivacyPolicy // D1
+	Console github.PrivacyPolicy // 標準出力（GitHub Actions のログは公開されるため既定は hide）
+}
+
+// 環境変数から出力先ごとのプライバシー設定を読み込む
+func loadPrivacyConfig() (privacyConfig, error) {
+	var cfg privacyConfig
+	aliases := os.Getenv("PRIVATE_REPO_ALIASES")
+
+	policies := map[string]*github.PrivacyPolicy{
+		"PRIVACY_JSON":  &cfg.JSON,
+		"PRIVACY_EMAIL": &cfg.Email,
+		"PRIVACY_D1":    &cfg.D1,
+	}
+	// 未設定の場合、ファイル・メール・D1 はそのまま表示し、標準出力はプライベートリポジトリを除外する
+	defaults := map[string]string{"PRIVACY_CONSOLE": github.PrivacyHide}
+	policies["PRIVACY_CONSOLE"] = &cfg.Console
+	for key, target := range policies {
+		spec := os.Getenv(key)
+		if spec == "" {
+			spec = defaults[key]
+		}
+		policy, err := github.ParsePrivacyPolicy(spec, aliases)
+		if err != nil {
+			return cfg, fmt.Errorf("%s の値が不正です: %w", key, err)
+		}
+		*target = policy
+	}
+	return cfg, nil
+}
+
+// 環境変数から勤務時間・勤務日と注意の閾値を読み込む
+func loadWorkSchedule() (github.WorkSchedule, error) {
+	schedule, err := github.ParseWorkSchedule(os.Getenv("WORK_HOURS"), os.Getenv("WORK_DAYS"), os.Getenv("LATE_NIGHT_HOURS"))
+	if err != nil {
+		return schedule, err
+	}
+
+	thresholds := map[string]*int{
+		"WORKLIFE_AFTER_HOURS_SHARE":  &schedule.Thresholds.AfterHoursShare,
+		"WORKLIFE_WEEKEND_SHARE":      &schedule.Thresholds.WeekendShare,
+		"WORKLIFE_LATE_NIGHT_COMMITS": &schedule.Thresholds.LateNightCommits,
+		"WORKLIFE_WARNING_WEEKS":      &schedule.Thresholds.Weeks,
+	}
+	for key, target := range thresholds {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return schedule, fmt.Errorf("%s の値が不正です: %w", key, err)
+		}
+		*target = n
+	}
+	return schedule, nil
 }
 
 func printWeeklyComparison(comp *github.WeeklyComparison) {
+	lang := i18n.Console()
 	current := comp.CurrentWeek
 	previous := comp.PreviousWeek
 
 	fmt.Println("========================================")
-	fmt.Println("週間コミットレポート（前週比）")
+	fmt.Println(lang.T("console.comparison"))
 	fmt.Println("========================================")
 
 	// 今週の期間
-	fmt.Printf("\n📅 今週: %s 〜 %s\n",
+	fmt.Println("\n" + lang.T("console.this_week",
 		current.StartDate.Format("2006-01-02"),
-		current.EndDate.Format("2006-01-02"))
-	fmt.Printf("📅 先週: %s 〜 %s\n",
+		current.EndDate.Format("2006-01-02")))
+	fmt.Println(lang.T("console.last_week",
 		previous.StartDate.Format("2006-01-02"),
-		previous.EndDate.Format("2006-01-02"))
+		previous.EndDate.Format("2006-01-02")))
 
 	// コミット数比較
-	fmt.Println("\n📊 総コミット数:")
-	fmt.Printf("  今週: %d\n", current.TotalCommits)
-	fmt.Printf("  先週: %d\n", previous.TotalCommits)
+	fmt.Println("\n" + lang.T("console.total_commits"))
+	fmt.Println(lang.T("console.commits_current", current.TotalCommits))
+	fmt.Println(lang.T("console.commits_previous", previous.TotalCommits))
 
 	// 差分と変化率を表示
 	if comp.CommitsDiff > 0 {
-		fmt.Printf("  📈 %+d (%d%% 増加)\n", comp.CommitsDiff, comp.CommitsChangeRate)
+		fmt.Println(lang.T("console.increase", comp.CommitsDiff, comp.CommitsChangeRate))
 	} else if comp.CommitsDiff < 0 {
-		fmt.Printf("  📉 %d (%d%% 減少)\n", comp.CommitsDiff, -comp.CommitsChangeRate)
+		fmt.Println(lang.T("console.decrease", comp.CommitsDiff, -comp.CommitsChangeRate))
 	} else {
-		fmt.Printf("  ➡️  変化なし\n")
+		fmt.Println(lang.T("console.no_change"))
+	}
+
+	// 継続記録
+	if comp.Streaks != nil {
+		fmt.Println("\n" + lang.T("console.streaks"))
+		fmt.Println(lang.T("console.streak_days", comp.Streaks.CurrentDays, comp.Streaks.LongestDays))
+		fmt.Println(lang.T("console.streak_weeks", comp.Streaks.CurrentWeeks, comp.Streaks.LongestWeeks))
+	}
+
+	// 目標の達成状況
+	if len(current.Goals) > 0 {
+		hitRates := make(map[string]github.GoalSummary)
+		for _, summary := range comp.GoalSummaries {
+			hitRates[summary.Metric] = summary
+		}
+		fmt.Println("\n" + lang.T("console.goals"))
+		for _, goal := range current.Goals {
+			mark := "  "
+			if goal.Achieved {
+				mark = "✅"
+			}
+			summary := hitRates[goal.Metric]
+			fmt.Println(lang.T("console.goal",
+				mark, lang.Label("goal", goal.Metric), goal.Actual, goal.Target, goal.Percent, summary.HitRate, summary.Achieved, summary.Weeks))
+		}
+	}
+
+	// 除外したコミット
+	if excluded := current.ExcludedCommits; excluded.Total > 0 || excluded.IgnoredFiles > 0 {
+		fmt.Println("\n" + lang.T("console.excluded"))
+		fmt.Println(lang.T("console.excluded_total", excluded.Total, excluded.Bots, excluded.Messages, excluded.Paths))
+		fmt.Println(lang.T("console.ignored_files", excluded.IgnoredFiles))
+	}
+
+	// コミットの種類
+	if len(current.CommitTypes) > 0 {
+		fmt.Println("\n" + lang.T("console.commit_types"))
+		for _, commitType := range slices.Sorted(maps.Keys(current.CommitTypes)) {
+			fmt.Printf("  %-10s %4d\n", commitType, current.CommitTypes[commitType])
+		}
+	}
+
+	// ワークライフバランス
+	fmt.Println("\n" + lang.T("console.worklife"))
+	fmt.Println(lang.T("console.after_hours", current.WorkLife.AfterHoursCommits, current.WorkLife.AfterHoursShare))
+	fmt.Println(lang.T("console.weekend", current.WorkLife.WeekendCommits, current.WorkLife.WeekendShare))
+	fmt.Println(lang.T("console.late_night", current.WorkLife.LateNightCommits))
+	if comp.WorkLifeWarning != nil {
+		fmt.Println(lang.T("console.worklife_warning", comp.WorkLifeWarning.ConsecutiveWeeks))
+	}
+
+	// 平均・昨年同週との比較
+	if comp.Trends != nil {
+		commits := comp.Trends.Commits
+		fmt.Println("\n" + lang.T("console.trends"))
+		fmt.Println(lang.T("console.average_4weeks", commits.Average4Weeks, commits.ChangeRate4Weeks))
+		fmt.Println(lang.T("console.average_12weeks", commits.Average12Weeks, commits.ChangeRate12Weeks))
+		if commits.HasLastYear {
+			fmt.Println(lang.T("console.last_year", commits.LastYear))
+		}
+		fmt.Println(lang.T("console.direction", commits.Direction))
 	}
 
 	// リポジトリ別比較
-	fmt.Println("\n📁 リポジトリ別コミット数:")
-	fmt.Println("  リポジトリ名          今週  先週  差分")
+	fmt.Println("\n" + lang.T("console.repos"))
+	fmt.Println(lang.T("console.repos_header"))
 	fmt.Println("  " + strings.Repeat("-", 45))
 
 	// RepoDetails から map を生成
@@ -171,21 +594,21 @@ func printWeeklyComparison(comp *github.WeeklyComparison) {
 	}
 
 	// 言語別比較
-	fmt.Println("\n💻 言語別変更ファイル数:")
-	fmt.Println("  言語                  今週  先週  差分")
+	fmt.Println("\n" + lang.T("console.languages"))
+	fmt.Println(lang.T("console.languages_header"))
 	fmt.Println("  " + strings.Repeat("-", 45))
 
 	allLangs := make(map[string]bool)
-	for lang := range current.LanguageCommits {
-		allLangs[lang] = true
+	for language := range current.LanguageCommits {
+		allLangs[language] = true
 	}
-	for lang := range previous.LanguageCommits {
-		allLangs[lang] = true
+	for language := range previous.LanguageCommits {
+		allLangs[language] = true
 	}
 
-	for lang := range allLangs {
-		currentCount := current.LanguageCommits[lang]
-		previousCount := previous.LanguageCommits[lang]
+	for language := range allLangs {
+		currentCount := current.LanguageCommits[language]
+		previousCount := previous.LanguageCommits[language]
 		diff := currentCount - previousCount
 
 		diffStr := ""
@@ -197,6 +620,6 @@ func printWeeklyComparison(comp *github.WeeklyComparison) {
 			diffStr = "0"
 		}
 
-		fmt.Printf("  %-20s %4d  %4d  %s\n", lang, currentCount, previousCount, diffStr)
+		fmt.Printf("  %-20s %4d  %4d  %s\n", language, currentCount, previousCount, diffStr)
 	}
 }
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github-weekly-log/internal/email"
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// preview サブコマンド
+// 週間レポートのメールをローカルの HTTP サーバーで表示し、テンプレートの変更時に再読み込みする
+//
+//	fetcher preview                        モックデータで表示
+//	fetcher preview -json 2026-02-20.json  保存済みの JSON で表示
+//	fetcher preview -live                  GitHub から今週のデータを取得して表示
+//	fetcher preview -lang en               英語で表示（未指定の場合は REPORT_LANGUAGE）
+//
+// 監視するのはビルド済みの HTML（templates/dist）のみ。mjml の編集を反映するには
+// pnpm watch:email（npx mjml -w）を並行して起動しておく
+func runPreviewCommand(args []string, reportLang i18n.Language) error {
+	flags := flag.NewFlagSet("preview", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "待ち受けるアドレス")
+	jsonPath := flags.String("json", "", "表示する週の JSON ファイル（fetcher が出力したもの）")
+	live := flags.Bool("live", false, "GitHub から今週のデータを取得して表示する")
+	langName := flags.String("lang", string(reportLang), "表示する言語（ja / en）")
+	templateDir := flags.String("templates", "templates/dist", "監視・読み込みするテンプレートのディレクトリ（EMAIL_TEMPLATE_DIR が優先）")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *live && *jsonPath != "" {
+		return fmt.Errorf("-live と -json は同時に指定できません")
+	}
+	lang, err := i18n.Parse(*langName)
+	if err != nil {
+		return err
+	}
+
+	// テンプレートはリクエストごとに監視先から読み込む
+	watchDir := os.Getenv("EMAIL_TEMPLATE_DIR")
+	if watchDir == "" {
+		watchDir = *templateDir
+	}
+	if err := email.SetTemplateDir(watchDir); err != nil {
+		return fmt.Errorf("テンプレートディレクトリが不正です: %w", err)
+	}
+
+	comparison, err := loadPreviewData(*jsonPath, *live)
+	if err != nil {
+		return err
+	}
+
+	preview, err := email.NewPreview(comparison, lang, watchDir)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return preview.ListenAndServe(ctx, *addr)
+}
+
+// プレビューに表示する週間データを読み込む
+func loadPreviewData(jsonPath string, live bool) (github.WeeklyComparison, error) {
+	switch {
+	case jsonPath != "":
+		return loadComparisonJSON(jsonPath)
+
+	case live:
+		privacy, err := loadPrivacyConfig()
+		if err != nil {
+			return github.WeeklyComparison{}, err
+		}
+		client, err := loadGitHubClient(os.Getenv("GITHUB_TOKEN"))
+		if err != nil {
+			return github.WeeklyComparison{}, err
+		}
+		fmt.Println("Start scanning")
+		comparison, err := client.FetchWeeklyCommitsWithComparison(context.Background(), os.Getenv("GITHUB_USER"))
+		if err != nil {
+			return github.WeeklyComparison{}, err
+		}
+		return *privacy.Email.Apply(comparison), nil
+
+	default:
+		return email.SampleWeeklyComparison(time.Now()), nil
+	}
+}
+
+// fetcher が出力した週間データの JSON を読み込む
+func loadComparisonJSON(path string) (github.WeeklyComparison, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return github.WeeklyComparison{}, err
+	}
+	var comparison github.WeeklyComparison
+	if err := json.Unmarshal(data, &comparison); err != nil {
+		return github.WeeklyComparison{}, fmt.Errorf("JSON の読み込みに失敗しました (%s): %w", path, err)
+	}
+	// 今週のデータがない JSON は表示できないためエラーにする
+	// （先週のデータがない場合は、表示時に先週のコミットがなかったものとして扱う）
+	if comparison.CurrentWeek == nil {
+		return github.WeeklyComparison{}, fmt.Errorf("週間データの JSON ではありません: %s", path)
+	}
+	return comparison, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"os"
+)
+
+// repos サブコマンド
+// list: 集計対象になるリポジトリと、除外されたリポジトリのルールを表示する
+func runReposCommand(args []string) error {
+	if len(args) == 0 || args[0] != "list" {
+		return fmt.Errorf("使い方: fetcher repos list")
+	}
+
+	filter, err := loadRepoFilter()
+	if err != nil {
+		return err
+	}
+	client := github.NewClientWithOptions(os.Getenv("GITHUB_TOKEN"), github.Options{RepoFilter: &filter})
+
+	decisions, err := client.ListRepositoryDecisions(context.Background(), os.Getenv("GITHUB_USER"))
+	if err != nil {
+		return err
+	}
+	printRepoDecisions(decisions)
+	return nil
+}
+
+// 判定結果を対象・除外の順に表示
+func printRepoDecisions(decisions []github.RepoDecision) {
+	lang := i18n.Console()
+	included := 0
+	fmt.Println(lang.T("console.repos_included"))
+	for _, decision := range decisions {
+		if decision.Included {
+			included++
+			fmt.Printf("  ✅ %s\n", decision.Repo)
+		}
+	}
+
+	fmt.Println("\n" + lang.T("console.repos_excluded"))
+	fmt.Printf("  %-40s %s\n", lang.T("console.repo"), lang.T("console.rule"))
+	for _, decision := range decisions {
+		if !decision.Included {
+			fmt.Printf("  %-40s %s\n", decision.Repo, decision.Rule)
+		}
+	}
+
+	fmt.Println("\n" + lang.T("console.repos_total", len(decisions), included, len(decisions)-included))
+}
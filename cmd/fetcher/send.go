@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github-weekly-log/internal/database"
+	"github-weekly-log/internal/email"
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+)
+
+// send サブコマンド
+// D1 に送信済みとして記録されていない送信先にだけ週間レポートを送信する（D1 への保存は行わない）
+//
+//	fetcher send                                  GitHub から今週のデータを取得して送信
+//	fetcher send 2026-02-13.json 2026-02-20.json  保存済みの週をそれぞれ送信
+//	fetcher send -pending 4                       D1 に保存済みの直近4週のうち、未送信の送信先がある週を取得し直して送信
+func runSendCommand(args []string, reportLang i18n.Language) error {
+	flags := flag.NewFlagSet("send", flag.ContinueOnError)
+	pendingWeeks := flags.Int("pending", 0, "D1 に保存済みの直近 N 週から未送信の送信先がある週を探し、GitHub から取得し直して送信する")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *pendingWeeks > 0 && flags.NArg() > 0 {
+		return fmt.Errorf("-pending と JSON ファイルは同時に指定できません")
+	}
+
+	accountID := os.Getenv("D1_ACCOUNT_ID")
+	databaseID := loadD1DatabaseID()
+	if accountID == "" || databaseID == "" {
+		return fmt.Errorf("send には送信済みの記録を確認するため D1 の設定が必要です")
+	}
+	cfClient := database.InitD1(os.Getenv("D1_API_TOKEN"), accountID)
+
+	privacy, err := loadPrivacyConfig()
+	if err != nil {
+		return err
+	}
+	sender, err := loadEmailSender()
+	if err != nil {
+		return err
+	}
+	recipients, err := loadRecipients(reportLang)
+	if err != nil {
+		return err
+	}
+
+	deliveries := newDeliveryStore(cfClient, accountID, databaseID)
+	var weeks []*github.WeeklyComparison
+	if *pendingWeeks > 0 {
+		client, err := loadGitHubClient(os.Getenv("GITHUB_TOKEN"))
+		if err != nil {
+			return err
+		}
+		weeks, err = loadPendingWeeks(client, cfClient, accountID, databaseID, privacy.D1, deliveries, recipients, os.Getenv("GITHUB_USER"), *pendingWeeks)
+		if err != nil {
+			return err
+		}
+	} else if flags.NArg() == 0 {
+		client, err := loadGitHubClient(os.Getenv("GITHUB_TOKEN"))
+		if err != nil {
+			return err
+		}
+		fmt.Println("Start scanning")
+		comparison, err := client.FetchWeeklyCommitsWithComparison(context.Background(), os.Getenv("GITHUB_USER"))
+		if err != nil {
+			return err
+		}
+		applyHistory(client, cfClient, accountID, databaseID, privacy.D1, comparison)
+		weeks = append(weeks, comparison)
+	}
+	for _, path := range flags.Args() {
+		comparison, err := loadComparisonJSON(path)
+		if err != nil {
+			return err
+		}
+		weeks = append(weeks, &comparison)
+	}
+
+	// 週ごとに送信し、失敗しても残りの週の送信は続ける
+	var errs []error
+	for _, comparison := range weeks {
+		current := comparison.CurrentWeek
+		fmt.Printf("Send weekly report email (%s)\n", current.StartDate.Format("2006-01-02"))
+		if err := deliverWeeklyReports(sender, deliveries, *privacy.Email.Apply(comparison), recipients, os.Getenv("RESEND_EMAIL_DOMAIN")); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// D1 に保存済みの直近 n 週のうち、未送信の送信先がある週を GitHub から取得し直す（古い順）
+func loadPendingWeeks(client *github.Client, cfClient *cloudflare.Client, accountID, databaseID string, d1Privacy github.PrivacyPolicy, deliveries *deliveryStore, recipients []email.Recipient, username string, n int) ([]*github.WeeklyComparison, error) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	starts, err := database.LoadStoredWeeks(context.Background(), cfClient, accountID, databaseID, username, n, jst)
+	if err != nil {
+		return nil, err
+	}
+
+	var weeks []*github.WeeklyComparison
+	for _, startDate := range slices.Backward(starts) {
+		pending, err := deliveries.hasPending(username, startDate, recipients)
+		if err != nil {
+			return nil, err
+		}
+		if !pending {
+			continue
+		}
+		fmt.Printf("Start scanning (%s)\n", startDate.Format("2006-01-02"))
+		comparison, err := client.FetchWeeklyCommitsWithComparisonFrom(context.Background(), username, startDate)
+		if err != nil {
+			return nil, err
+		}
+		applyHistory(client, cfClient, accountID, databaseID, d1Privacy, comparison)
+		weeks = append(weeks, comparison)
+	}
+	if len(weeks) == 0 {
+		i18n.Println("console.no_pending_weeks", len(starts))
+	}
+	return weeks, nil
+}
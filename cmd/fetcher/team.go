@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-weekly-log/internal/database"
+	"github-weekly-log/internal/document"
+	"github-weekly-log/internal/email"
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+)
+
+// チームモードの設定
+type teamConfig struct {
+	Members      []string          // 集計対象のGitHubユーザー名
+	MemberEmails map[string]string // ユーザー名 → 個人レポートの送信先
+	LeadEmail    string            // チームダイジェストの送信先
+	Language     i18n.Language     // 個人レポートとチームダイジェストの言語
+}
+
+// 環境変数からチームモードの設定を読み込む
+// GITHUB_USERS:       "alice,bob"
+// TEAM_MEMBER_EMAILS: "alice:alice@example.com,bob:bob@example.com"
+// TEAM_LEAD_EMAIL:    "lead@example.com"
+func parseTeamConfig(users, memberEmails, leadEmail string) teamConfig {
+	cfg := teamConfig{
+		MemberEmails: make(map[string]string),
+		LeadEmail:    strings.TrimSpace(leadEmail),
+	}
+
+	for _, user := range strings.Split(users, ",") {
+		user = strings.TrimSpace(user)
+		if user != "" {
+			cfg.Members = append(cfg.Members, user)
+		}
+	}
+
+	for _, entry := range strings.Split(memberEmails, ",") {
+		user, addr, ok := strings.Cut(entry, ":")
+		if !ok {
+			continue
+		}
+		user = strings.TrimSpace(user)
+		addr = strings.TrimSpace(addr)
+		if user != "" && addr != "" {
+			cfg.MemberEmails[user] = addr
+		}
+	}
+
+	return cfg
+}
+
+// チームモードの実行：メンバーごとの集計・保存・送信と、チームダイジェストの送信
+func runTeamReport(client *github.Client, cfg teamConfig, privacy privacyConfig, cfClient *cloudflare.Client, accountID, databaseID string, sender email.Sender, emailDomain string) error {
+	fmt.Println("Start scanning team")
+	report, err := client.FetchTeamWeeklyCommits(context.Background(), cfg.Members)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Finished scanning team")
+
+	if cfClient != nil {
+		// D1の履歴から継続記録などを計算
+		for _, member := range report.Members {
+			applyHistory(client, cfClient, accountID, databaseID, privacy.D1, member.Comparison)
+		}
+	}
+
+	for _, member := range report.Members {
+		fmt.Printf("\n👤 %s\n", member.Username)
+		printWeeklyComparison(privacy.Console.Apply(member.Comparison))
+	}
+
+	// JSON・Markdownファイル生成
+	jsonData := privacy.JSON.ApplyTeam(report)
+	if err := document.GenerateTeamJSONData(jsonData); err != nil {
+		return err
+	}
+	if err := document.GenerateTeamMarkdownData(jsonData, cfg.Language); err != nil {
+		return err
+	}
+	fmt.Println("Finished generating")
+
+	if cfClient != nil {
+		// D1にメンバーごとに保存
+		fmt.Println("Save to D1")
+		for _, member := range report.Members {
+			err := database.SaveWeeklyStatsToD1WithTransaction(context.Background(), cfClient, accountID, databaseID, privacy.D1.Apply(member.Comparison))
+			if err != nil {
+				return fmt.Errorf("%s の保存に失敗しました: %w", member.Username, err)
+			}
+		}
+	}
+
+	// メンバー個人へのレポート送信（送信済みのメンバーはスキップし、失敗しても残りのメンバーには送信する）
+	deliveries := newDeliveryStore(cfClient, accountID, databaseID)
+	var sendErrs []error
+	for _, member := range report.Members {
+		emailTo, exists := cfg.MemberEmails[member.Username]
+		if !exists {
+			i18n.Println("console.member_skipped", member.Username)
+			continue
+		}
+
+		fmt.Printf("Send weekly report email to %s\n", member.Username)
+		recipients := []email.Recipient{{Address: emailTo, Language: cfg.Language}}
+		err := deliverWeeklyReports(sender, deliveries, *privacy.Email.Apply(member.Comparison), recipients, emailDomain)
+		if err != nil {
+			sendErrs = append(sendErrs, err)
+		}
+	}
+
+	// チームリーダーへのダイジェスト送信（メンバーへの送信に失敗した場合も送信する）
+	if cfg.LeadEmail == "" {
+		i18n.Println("console.team_skipped")
+		return errors.Join(sendErrs...)
+	}
+	err = deliverTeamDigest(sender, deliveries, *privacy.Email.ApplyTeam(report), cfg.Language, emailDomain, cfg.LeadEmail)
+	return errors.Join(append(sendErrs, err)...)
+}
+
+// チームダイジェストを送信済みでない場合だけ送信し、送信できた場合は記録する
+// 送信に失敗した場合は errNotDelivered を含むエラーを返す
+func deliverTeamDigest(sender email.Sender, store *deliveryStore, teamData github.TeamReport, lang i18n.Language, emailDomain, leadEmail string) error {
+	name := email.TeamDeliveryName(teamData)
+	startDate := teamData.Team.StartDate
+	key := email.TeamDeliveryKey(teamData, leadEmail)
+	delivered, err := store.delivered(name, startDate, key)
+	if err != nil {
+		return err
+	}
+	if delivered {
+		i18n.Println("console.delivery_skipped", leadEmail)
+		return nil
+	}
+
+	htmlContent, err := email.LoadTeamTemplate(teamData, lang)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Send team digest email")
+	id, err := email.SendTeamReport(sender, htmlContent, email.RenderTeamText(teamData, lang), lang, emailDomain, leadEmail, key)
+	results := []email.SendResult{{Recipient: email.Recipient{Address: leadEmail, Language: lang}, Key: key, ID: id, Err: err}}
+	recordDeliveries(store, name, startDate, results)
+	return printSendResults(results)
+}
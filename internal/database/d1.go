@@ -2,9 +2,10 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github-weekly-log/internal/github"
-	"log"
+	"github-weekly-log/internal/i18n"
 	"strconv"
 	"time"
 
@@ -21,36 +22,55 @@ func InitD1(apiToken string, accountID string) *cloudflare.Client {
 	return cfClient
 }
 
+// username を追加する前に保存した行（username が空）を指定したユーザーのデータとして引き継ぐ
+// 同じ週のデータがすでにある場合は引き継がずに残す
+func ClaimLegacyWeeklyStats(ctx context.Context, client *cloudflare.Client, accountID, databaseID, username string) error {
+	if username == "" {
+		return nil
+	}
+	_, err := queryRows(ctx, client, accountID, databaseID, `
+		UPDATE OR IGNORE weekly_stats
+		SET username = ?
+		WHERE username = ''`,
+		username)
+	if err != nil {
+		return fmt.Errorf("weekly_stats のユーザー名の引き継ぎエラー: %w", err)
+	}
+	return nil
+}
+
 // D1に週間コミットデータを保存する関数
-func SaveWeeklyStatsToD1WithTransaction(ctx context.Context, client *cloudflare.Client, accountID, databaseID string, stats *github.WeeklyStats) error {
-	log.Println("[INFO] データ保存処理を開始します")
+// 今週のデータと、比較データから計算した継続記録などを保存する
+func SaveWeeklyStatsToD1WithTransaction(ctx context.Context, client *cloudflare.Client, accountID, databaseID string, comparison *github.WeeklyComparison) error {
+	i18n.Logf("log.save_start")
+	stats := comparison.CurrentWeek
 
 	// weekly_stats を挿入（UPSERT）
-	log.Println("[INFO] weekly_stats テーブルへの挿入を開始")
-	weeklyStatsID, err := insertWeeklyStats(ctx, client, accountID, databaseID, stats)
+	i18n.Logf("log.stats_insert")
+	weeklyStatsID, err := insertWeeklyStats(ctx, client, accountID, databaseID, comparison)
 	if err != nil {
-		log.Printf("[ERROR] weekly_stats の挿入に失敗しました: %v", err)
+		i18n.Logf("log.stats_failed", err)
 		return fmt.Errorf("weekly_stats挿入エラー: %w", err)
 	}
-	log.Printf("[INFO] weekly_stats を挿入しました (ID: %s)", weeklyStatsID)
+	i18n.Logf("log.stats_inserted", weeklyStatsID)
 
 	// 既存の子データを削除
-	log.Println("[INFO] 既存の子データを削除中")
+	i18n.Logf("log.children_delete")
 	err = deleteChildData(ctx, client, accountID, databaseID, weeklyStatsID)
 	if err != nil {
-		log.Printf("[WARN] 既存データの削除に失敗しました: %v", err)
+		i18n.Logf("log.delete_failed", err)
 	}
 
 	// 子データを一括挿入
-	log.Println("[INFO] 子データの挿入を開始")
+	i18n.Logf("log.children_insert")
 	err = insertChildData(ctx, client, accountID, databaseID, weeklyStatsID, stats)
 	if err != nil {
-		log.Printf("[ERROR] 子データの挿入に失敗しました: %v", err)
+		i18n.Logf("log.children_failed", err)
 		return fmt.Errorf("子データ挿入エラー: %w", err)
 	}
 
-	log.Printf("[INFO] データ保存が完了しました (ID: %s, commits: %d, active_days: %d)",
-		weeklyStatsID, stats.TotalCommits, stats.ActiveDays)
+	i18n.Logf("log.save_done",
+		weeklyStatsID, stats.Username, stats.TotalCommits, stats.ActiveDays)
 	return nil
 }
 
@@ -74,6 +94,22 @@ func deleteChildData(ctx context.Context, client *cloudflare.Client, accountID,
 		Sql:    cloudflare.F(`DELETE FROM daily_commits WHERE weekly_stats_id = ?`),
 		Params: cloudflare.F([]string{weeklyStatsID}),
 	})
+	batch = append(batch, d1.DatabaseQueryParamsBodyMultipleQueriesBatch{
+		Sql:    cloudflare.F(`DELETE FROM weekday_hourly_activity WHERE weekly_stats_id = ?`),
+		Params: cloudflare.F([]string{weeklyStatsID}),
+	})
+	batch = append(batch, d1.DatabaseQueryParamsBodyMultipleQueriesBatch{
+		Sql:    cloudflare.F(`DELETE FROM commit_types WHERE weekly_stats_id = ?`),
+		Params: cloudflare.F([]string{weeklyStatsID}),
+	})
+	batch = append(batch, d1.DatabaseQueryParamsBodyMultipleQueriesBatch{
+		Sql:    cloudflare.F(`DELETE FROM commit_scopes WHERE weekly_stats_id = ?`),
+		Params: cloudflare.F([]string{weeklyStatsID}),
+	})
+	batch = append(batch, d1.DatabaseQueryParamsBodyMultipleQueriesBatch{
+		Sql:    cloudflare.F(`DELETE FROM goal_results WHERE weekly_stats_id = ?`),
+		Params: cloudflare.F([]string{weeklyStatsID}),
+	})
 
 	result, err := client.D1.Database.Query(ctx, databaseID, d1.DatabaseQueryParams{
 		AccountID: cloudflare.F(accountID),
@@ -91,33 +127,49 @@ func deleteChildData(ctx context.Context, client *cloudflare.Client, accountID,
 	}
 
 	if totalDeleted > 0 {
-		log.Printf("[INFO] 既存の子データを削除しました (%d件)", totalDeleted)
+		i18n.Logf("log.children_deleted", totalDeleted)
 	}
 
 	return nil
 }
 
 // weekly_stats を挿入して ID を返す
-func insertWeeklyStats(ctx context.Context, client *cloudflare.Client, accountID, databaseID string, stats *github.WeeklyStats) (string, error) {
+func insertWeeklyStats(ctx context.Context, client *cloudflare.Client, accountID, databaseID string, comparison *github.WeeklyComparison) (string, error) {
+	stats := comparison.CurrentWeek
+	streaks := comparison.Streaks
+	if streaks == nil {
+		streaks = &github.Streaks{}
+	}
+
 	result, err := client.D1.Database.Query(ctx, databaseID, d1.DatabaseQueryParams{
 		AccountID: cloudflare.F(accountID),
 		Body: d1.DatabaseQueryParamsBodyD1SingleQuery{
 			Sql: cloudflare.F(`
-				INSERT INTO weekly_stats (start_date, end_date, total_commits, active_days, created_at)
-				VALUES (?, ?, ?, ?, ?)
-				ON CONFLICT(start_date) DO UPDATE SET
+				INSERT INTO weekly_stats (username, start_date, end_date, total_commits, active_days, created_at,
+					current_day_streak, longest_day_streak, current_week_streak, longest_week_streak)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT(username, start_date) DO UPDATE SET
 					end_date = excluded.end_date,
 					total_commits = excluded.total_commits,
 					active_days = excluded.active_days,
-					created_at = excluded.created_at
+					created_at = excluded.created_at,
+					current_day_streak = excluded.current_day_streak,
+					longest_day_streak = excluded.longest_day_streak,
+					current_week_streak = excluded.current_week_streak,
+					longest_week_streak = excluded.longest_week_streak
 				RETURNING id
 			`),
 			Params: cloudflare.F([]string{
+				stats.Username,
 				stats.StartDate.Format("2006-01-02"),
 				stats.EndDate.Format("2006-01-02"),
 				strconv.Itoa(stats.TotalCommits),
 				strconv.Itoa(stats.ActiveDays),
 				time.Now().Format(time.RFC3339),
+				strconv.Itoa(streaks.CurrentDays),
+				strconv.Itoa(streaks.LongestDays),
+				strconv.Itoa(streaks.CurrentWeeks),
+				strconv.Itoa(streaks.LongestWeeks),
 			}),
 		},
 	})
@@ -190,15 +242,57 @@ func insertChildData(ctx context.Context, client *cloudflare.Client, accountID,
 		}
 	}
 
+	// weekday_hourly_activity
+	heatmapCount := 0
+	for weekday, hours := range stats.WeekdayHourlyActivity {
+		for hour, commits := range hours {
+			if commits > 0 {
+				heatmapCount++
+				batch = append(batch, d1.DatabaseQueryParamsBodyMultipleQueriesBatch{
+					Sql: cloudflare.F(`INSERT INTO weekday_hourly_activity (weekly_stats_id, weekday, hour, commits) VALUES (?, ?, ?, ?)`),
+					Params: cloudflare.F([]string{
+						weeklyStatsID,
+						strconv.Itoa(weekday),
+						strconv.Itoa(hour),
+						strconv.Itoa(commits),
+					}),
+				})
+			}
+		}
+	}
+
 	// repo_details
 	for _, repo := range stats.RepoDetails {
+		languages := []byte("{}")
+		if len(repo.Languages) > 0 {
+			encoded, err := json.Marshal(repo.Languages)
+			if err != nil {
+				return fmt.Errorf("言語データの変換に失敗しました (%s): %w", repo.Name, err)
+			}
+			languages = encoded
+		}
+		isPrivate := "0"
+		if repo.Private {
+			isPrivate = "1"
+		}
+		pushedAt := ""
+		if !repo.PushedAt.IsZero() {
+			pushedAt = repo.PushedAt.Format(time.RFC3339)
+		}
 		batch = append(batch, d1.DatabaseQueryParamsBodyMultipleQueriesBatch{
-			Sql: cloudflare.F(`INSERT INTO repo_details (weekly_stats_id, repo_name, commits, bar_width) VALUES (?, ?, ?, ?)`),
+			Sql: cloudflare.F(`INSERT INTO repo_details (weekly_stats_id, repo_name, commits, bar_width, additions, deletions, private, primary_language, url, pushed_at, languages) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
 			Params: cloudflare.F([]string{
 				weeklyStatsID,
 				repo.Name,
 				strconv.Itoa(repo.Count),
 				strconv.Itoa(int(repo.BarPercent)),
+				strconv.Itoa(repo.Additions),
+				strconv.Itoa(repo.Deletions),
+				isPrivate,
+				repo.PrimaryLanguage,
+				repo.URL,
+				pushedAt,
+				string(languages),
 			}),
 		})
 	}
@@ -210,23 +304,72 @@ func insertChildData(ctx context.Context, client *cloudflare.Client, accountID,
 			isMain = "1"
 		}
 		batch = append(batch, d1.DatabaseQueryParamsBodyMultipleQueriesBatch{
-			Sql: cloudflare.F(`INSERT INTO language_commits (weekly_stats_id, language, commits, is_main) VALUES (?, ?, ?, ?)`),
+			Sql: cloudflare.F(`INSERT INTO language_commits (weekly_stats_id, language, commits, is_main, lines, commit_count) VALUES (?, ?, ?, ?, ?, ?)`),
 			Params: cloudflare.F([]string{
 				weeklyStatsID,
 				lang,
 				strconv.Itoa(commits),
 				isMain,
+				strconv.Itoa(stats.LanguageLines[lang]),
+				strconv.Itoa(stats.LanguageTouches[lang]),
+			}),
+		})
+	}
+
+	// commit_types
+	commitTypeCount := 0
+	for repoName, types := range stats.RepoCommitTypes {
+		for commitType, commits := range types {
+			commitTypeCount++
+			batch = append(batch, d1.DatabaseQueryParamsBodyMultipleQueriesBatch{
+				Sql: cloudflare.F(`INSERT INTO commit_types (weekly_stats_id, repo_name, commit_type, commits) VALUES (?, ?, ?, ?)`),
+				Params: cloudflare.F([]string{
+					weeklyStatsID,
+					repoName,
+					commitType,
+					strconv.Itoa(commits),
+				}),
+			})
+		}
+	}
+
+	// commit_scopes
+	for scope, commits := range stats.CommitScopes {
+		batch = append(batch, d1.DatabaseQueryParamsBodyMultipleQueriesBatch{
+			Sql: cloudflare.F(`INSERT INTO commit_scopes (weekly_stats_id, scope, commits) VALUES (?, ?, ?)`),
+			Params: cloudflare.F([]string{
+				weeklyStatsID,
+				scope,
+				strconv.Itoa(commits),
+			}),
+		})
+	}
+
+	// goal_results
+	for _, goal := range stats.Goals {
+		achieved := "0"
+		if goal.Achieved {
+			achieved = "1"
+		}
+		batch = append(batch, d1.DatabaseQueryParamsBodyMultipleQueriesBatch{
+			Sql: cloudflare.F(`INSERT INTO goal_results (weekly_stats_id, metric, target, actual, achieved) VALUES (?, ?, ?, ?, ?)`),
+			Params: cloudflare.F([]string{
+				weeklyStatsID,
+				goal.Metric,
+				strconv.Itoa(goal.Target),
+				strconv.Itoa(goal.Actual),
+				achieved,
 			}),
 		})
 	}
 
 	if len(batch) == 0 {
-		log.Println("[WARN] 挿入する子データがありません")
+		i18n.Logf("log.no_children")
 		return nil
 	}
 
-	log.Printf("[INFO] バッチ処理を実行します (daily: %d, hourly: %d, repos: %d, langs: %d, total: %d)",
-		len(stats.DailyCommits), hourlyCount, len(stats.RepoDetails), len(stats.LanguageCommits), len(batch))
+	i18n.Logf("log.batch",
+		len(stats.DailyCommits), hourlyCount, heatmapCount, len(stats.RepoDetails), len(stats.LanguageCommits), commitTypeCount, len(stats.CommitScopes), len(stats.Goals), len(batch))
 
 	result, err := client.D1.Database.Query(ctx, databaseID, d1.DatabaseQueryParams{
 		AccountID: cloudflare.F(accountID),
@@ -239,11 +382,11 @@ func insertChildData(ctx context.Context, client *cloudflare.Client, accountID,
 	// 結果確認
 	for i, queryResult := range result.Result {
 		if !queryResult.Success {
-			log.Printf("[ERROR] バッチ処理 #%d が失敗しました", i)
+			i18n.Logf("log.batch_failed", i)
 			return fmt.Errorf("バッチ #%d 実行エラー", i)
 		}
 	}
 
-	log.Printf("[INFO] 子データの挿入が完了しました (%d件)", len(result.Result))
+	i18n.Logf("log.children_done", len(result.Result))
 	return nil
 }
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/d1"
+)
+
+// 送信済みの週間レポート
+type Delivery struct {
+	Username       string
+	StartDate      time.Time // 週の開始日
+	Recipient      string    // 送信先のアドレス
+	IdempotencyKey string    // 週と送信先ごとの冪等キー
+	MessageID      string    // 送信サービス上のID
+}
+
+// 指定した週に送信済みの冪等キーを取得する
+func LoadDeliveredKeys(ctx context.Context, client *cloudflare.Client, accountID, databaseID, username string, startDate time.Time) (map[string]bool, error) {
+	rows, err := queryRows(ctx, client, accountID, databaseID, `
+		SELECT idempotency_key
+		FROM email_deliveries
+		WHERE username = ? AND start_date = ?`,
+		username, startDate.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("email_deliveries取得エラー: %w", err)
+	}
+
+	keys := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		keys[rowString(row, "idempotency_key")] = true
+	}
+	return keys, nil
+}
+
+// 送信済みとして記録する（記録済みの冪等キーは無視する）
+func RecordDeliveries(ctx context.Context, client *cloudflare.Client, accountID, databaseID string, deliveries []Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	deliveredAt := time.Now().Format(time.RFC3339)
+	var batch []d1.DatabaseQueryParamsBodyMultipleQueriesBatch
+	for _, delivery := range deliveries {
+		batch = append(batch, d1.DatabaseQueryParamsBodyMultipleQueriesBatch{
+			Sql: cloudflare.F(`
+				INSERT INTO email_deliveries (username, start_date, recipient, idempotency_key, message_id, delivered_at)
+				VALUES (?, ?, ?, ?, ?, ?)
+				ON CONFLICT(idempotency_key) DO NOTHING`),
+			Params: cloudflare.F([]string{
+				delivery.Username,
+				delivery.StartDate.Format("2006-01-02"),
+				delivery.Recipient,
+				delivery.IdempotencyKey,
+				delivery.MessageID,
+				deliveredAt,
+			}),
+		})
+	}
+
+	result, err := client.D1.Database.Query(ctx, databaseID, d1.DatabaseQueryParams{
+		AccountID: cloudflare.F(accountID),
+		Body:      d1.DatabaseQueryParamsBodyMultipleQueries{Batch: cloudflare.F(batch)},
+	})
+	if err != nil {
+		return fmt.Errorf("email_deliveries挿入エラー: %w", err)
+	}
+	for i, queryResult := range result.Result {
+		if !queryResult.Success {
+			return fmt.Errorf("email_deliveries #%d 挿入エラー", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"strconv"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/d1"
+)
+
+// D1から指定日より前の週間データを取得する（新しい順）
+// weeks が 0 以下の場合は全期間を取得する
+func LoadWeeklyHistory(ctx context.Context, client *cloudflare.Client, accountID, databaseID, username string, before time.Time, weeks int) ([]*github.WeeklyStats, error) {
+	limit := ""
+	if weeks > 0 {
+		limit = " LIMIT " + strconv.Itoa(weeks)
+	}
+
+	rows, err := queryRows(ctx, client, accountID, databaseID, `
+		SELECT id, start_date, end_date, total_commits, active_days
+		FROM weekly_stats
+		WHERE username = ? AND start_date < ?
+		ORDER BY start_date DESC`+limit,
+		username, before.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("weekly_stats取得エラー: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	jst := before.Location()
+	history := make([]*github.WeeklyStats, 0, len(rows))
+	byID := make(map[string]*github.WeeklyStats)
+	for _, row := range rows {
+		startDate, err := time.ParseInLocation("2006-01-02", rowString(row, "start_date"), jst)
+		if err != nil {
+			return nil, fmt.Errorf("start_date の解析に失敗しました: %w", err)
+		}
+		endDate, err := time.ParseInLocation("2006-01-02", rowString(row, "end_date"), jst)
+		if err != nil {
+			return nil, fmt.Errorf("end_date の解析に失敗しました: %w", err)
+		}
+
+		stats := &github.WeeklyStats{
+			Username:        username,
+			TotalCommits:    rowInt(row, "total_commits"),
+			ActiveDays:      rowInt(row, "active_days"),
+			StartDate:       startDate,
+			EndDate:         endDate,
+			LanguageCommits: make(map[string]int),
+		}
+		history = append(history, stats)
+		byID[rowString(row, "id")] = stats
+	}
+
+	// 日次データを取得
+	oldest := history[len(history)-1].StartDate
+	dailyRows, err := queryRows(ctx, client, accountID, databaseID, `
+		SELECT d.weekly_stats_id, d.date, d.commits
+		FROM daily_commits d
+		JOIN weekly_stats w ON w.id = d.weekly_stats_id
+		WHERE w.username = ? AND w.start_date >= ? AND w.start_date < ?
+		ORDER BY d.date ASC`,
+		username, oldest.Format("2006-01-02"), before.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("daily_commits取得エラー: %w", err)
+	}
+
+	for _, row := range dailyRows {
+		stats, exists := byID[rowString(row, "weekly_stats_id")]
+		if !exists {
+			continue
+		}
+		date, err := time.ParseInLocation("2006-01-02", rowString(row, "date"), jst)
+		if err != nil {
+			return nil, fmt.Errorf("date の解析に失敗しました: %w", err)
+		}
+		stats.DailyCommits = append(stats.DailyCommits, github.DailyCommit{
+			Date:    date,
+			DateStr: date.Format("1/2"),
+			Count:   rowInt(row, "commits"),
+		})
+	}
+
+	// リポジトリ別データを取得
+	repoRows, err := queryRows(ctx, client, accountID, databaseID, `
+		SELECT r.weekly_stats_id, r.repo_name, r.commits
+		FROM repo_details r
+		JOIN weekly_stats w ON w.id = r.weekly_stats_id
+		WHERE w.username = ? AND w.start_date >= ? AND w.start_date < ?
+		ORDER BY r.commits DESC`,
+		username, oldest.Format("2006-01-02"), before.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("repo_details取得エラー: %w", err)
+	}
+
+	for _, row := range repoRows {
+		stats, exists := byID[rowString(row, "weekly_stats_id")]
+		if !exists {
+			continue
+		}
+		stats.RepoDetails = append(stats.RepoDetails, github.RepoDetail{
+			Name:  rowString(row, "repo_name"),
+			Count: rowInt(row, "commits"),
+		})
+	}
+
+	// 言語別データを取得
+	langRows, err := queryRows(ctx, client, accountID, databaseID, `
+		SELECT l.weekly_stats_id, l.language, l.commits
+		FROM language_commits l
+		JOIN weekly_stats w ON w.id = l.weekly_stats_id
+		WHERE w.username = ? AND w.start_date >= ? AND w.start_date < ?`,
+		username, oldest.Format("2006-01-02"), before.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("language_commits取得エラー: %w", err)
+	}
+
+	for _, row := range langRows {
+		stats, exists := byID[rowString(row, "weekly_stats_id")]
+		if !exists {
+			continue
+		}
+		stats.LanguageCommits[rowString(row, "language")] = rowInt(row, "commits")
+	}
+
+	// 曜日×時間帯のデータを取得
+	heatmapRows, err := queryRows(ctx, client, accountID, databaseID, `
+		SELECT h.weekly_stats_id, h.weekday, h.hour, h.commits
+		FROM weekday_hourly_activity h
+		JOIN weekly_stats w ON w.id = h.weekly_stats_id
+		WHERE w.username = ? AND w.start_date >= ? AND w.start_date < ?`,
+		username, oldest.Format("2006-01-02"), before.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("weekday_hourly_activity取得エラー: %w", err)
+	}
+
+	for _, row := range heatmapRows {
+		stats, exists := byID[rowString(row, "weekly_stats_id")]
+		if !exists {
+			continue
+		}
+		weekday, hour := rowInt(row, "weekday"), rowInt(row, "hour")
+		if weekday < 0 || weekday > 6 || hour < 0 || hour > 23 {
+			continue
+		}
+		stats.WeekdayHourlyActivity[weekday][hour] = rowInt(row, "commits")
+	}
+
+	// 目標の達成状況を取得
+	goalRows, err := queryRows(ctx, client, accountID, databaseID, `
+		SELECT g.weekly_stats_id, g.metric, g.target, g.actual, g.achieved
+		FROM goal_results g
+		JOIN weekly_stats w ON w.id = g.weekly_stats_id
+		WHERE w.username = ? AND w.start_date >= ? AND w.start_date < ?
+		ORDER BY g.id ASC`,
+		username, oldest.Format("2006-01-02"), before.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("goal_results取得エラー: %w", err)
+	}
+
+	for _, row := range goalRows {
+		stats, exists := byID[rowString(row, "weekly_stats_id")]
+		if !exists {
+			continue
+		}
+		stats.Goals = append(stats.Goals, github.GoalResult{
+			Metric:   rowString(row, "metric"),
+			Target:   rowInt(row, "target"),
+			Actual:   rowInt(row, "actual"),
+			Achieved: rowInt(row, "achieved") == 1,
+		})
+	}
+
+	i18n.Logf("log.history",
+		len(history), len(dailyRows), len(repoRows), len(langRows), len(heatmapRows), len(goalRows))
+	return history, nil
+}
+
+// D1に保存済みの直近 weeks 週の開始日を取得する（新しい順、loc の0時として解析する）
+func LoadStoredWeeks(ctx context.Context, client *cloudflare.Client, accountID, databaseID, username string, weeks int, loc *time.Location) ([]time.Time, error) {
+	rows, err := queryRows(ctx, client, accountID, databaseID, `
+		SELECT start_date
+		FROM weekly_stats
+		WHERE username = ?
+		ORDER BY start_date DESC
+		LIMIT ?`,
+		username, strconv.Itoa(weeks))
+	if err != nil {
+		return nil, fmt.Errorf("weekly_stats取得エラー: %w", err)
+	}
+
+	starts := make([]time.Time, 0, len(rows))
+	for _, row := range rows {
+		startDate, err := time.ParseInLocation("2006-01-02", rowString(row, "start_date"), loc)
+		if err != nil {
+			return nil, fmt.Errorf("start_date の解析に失敗しました: %w", err)
+		}
+		starts = append(starts, startDate)
+	}
+	return starts, nil
+}
+
+// 単一クエリを実行して結果の行を返す
+func queryRows(ctx context.Context, client *cloudflare.Client, accountID, databaseID, sql string, params ...string) ([]map[string]interface{}, error) {
+	result, err := client.D1.Database.Query(ctx, databaseID, d1.DatabaseQueryParams{
+		AccountID: cloudflare.F(accountID),
+		Body: d1.DatabaseQueryParamsBodyD1SingleQuery{
+			Sql:    cloudflare.F(sql),
+			Params: cloudflare.F(params),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Result) == 0 {
+		return nil, nil
+	}
+
+	var rows []map[string]interface{}
+	for _, rowInterface := range result.Result[0].Results {
+		row, ok := rowInterface.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("結果の型変換に失敗しました: %T", rowInterface)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// 行から文字列値を取得
+func rowString(row map[string]interface{}, key string) string {
+	switch v := row[key].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return ""
+	}
+}
+
+// 行から整数値を取得
+func rowInt(row map[string]interface{}, key string) int {
+	switch v := row[key].(type) {
+	case float64:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,24 @@
+-- weekly_stats をユーザー単位で保存できるようにする
+-- 既存の行は username を空にして移行し、fetcher の次回実行時に GITHUB_USER のデータとして引き継ぐ
+-- （database.ClaimLegacyWeeklyStats）
+
+PRAGMA foreign_keys = OFF;
+
+CREATE TABLE weekly_stats_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT NOT NULL DEFAULT '',
+    start_date TEXT NOT NULL,
+    end_date TEXT NOT NULL,
+    total_commits INTEGER NOT NULL,
+    active_days INTEGER NOT NULL,
+    created_at TEXT NOT NULL,
+    UNIQUE(username, start_date)
+);
+
+INSERT INTO weekly_stats_new (id, username, start_date, end_date, total_commits, active_days, created_at)
+SELECT id, '', start_date, end_date, total_commits, active_days, created_at FROM weekly_stats;
+
+DROP TABLE weekly_stats;
+ALTER TABLE weekly_stats_new RENAME TO weekly_stats;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,4 @@
+-- 言語ごとの変更行数と変更コミット数を保存する
+-- commits は従来どおり変更ファイル数
+ALTER TABLE language_commits ADD COLUMN lines INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE language_commits ADD COLUMN commit_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- 継続記録（連続活動日数・週数）を保存する
+ALTER TABLE weekly_stats ADD COLUMN current_day_streak INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE weekly_stats ADD COLUMN longest_day_streak INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE weekly_stats ADD COLUMN current_week_streak INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE weekly_stats ADD COLUMN longest_week_streak INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+-- 曜日×時間帯のコミット数を保存する
+CREATE TABLE IF NOT EXISTS weekday_hourly_activity (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    weekly_stats_id INTEGER NOT NULL,
+    weekday INTEGER NOT NULL, -- 0=日曜日
+    hour INTEGER NOT NULL,
+    commits INTEGER NOT NULL,
+    FOREIGN KEY (weekly_stats_id) REFERENCES weekly_stats(id),
+    UNIQUE(weekly_stats_id, weekday, hour)
+);
@@ -0,0 +1,19 @@
+-- Conventional Commits の種類・スコープごとのコミット数を保存する
+CREATE TABLE IF NOT EXISTS commit_types (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    weekly_stats_id INTEGER NOT NULL,
+    repo_name TEXT NOT NULL,
+    commit_type TEXT NOT NULL, -- feat, fix などの Conventional Commits の種類（形式外は other）
+    commits INTEGER NOT NULL,
+    FOREIGN KEY (weekly_stats_id) REFERENCES weekly_stats(id),
+    UNIQUE(weekly_stats_id, repo_name, commit_type)
+);
+
+CREATE TABLE IF NOT EXISTS commit_scopes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    weekly_stats_id INTEGER NOT NULL,
+    scope TEXT NOT NULL,
+    commits INTEGER NOT NULL,
+    FOREIGN KEY (weekly_stats_id) REFERENCES weekly_stats(id),
+    UNIQUE(weekly_stats_id, scope)
+);
@@ -0,0 +1,8 @@
+-- リポジトリごとの変更行数・言語とメタデータを保存する
+ALTER TABLE repo_details ADD COLUMN additions INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE repo_details ADD COLUMN deletions INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE repo_details ADD COLUMN private BOOLEAN DEFAULT FALSE;
+ALTER TABLE repo_details ADD COLUMN primary_language TEXT NOT NULL DEFAULT '';
+ALTER TABLE repo_details ADD COLUMN url TEXT NOT NULL DEFAULT '';
+ALTER TABLE repo_details ADD COLUMN pushed_at TEXT NOT NULL DEFAULT '';
+ALTER TABLE repo_details ADD COLUMN languages TEXT NOT NULL DEFAULT '{}'; -- 言語ごとの変更ファイル数（JSON）
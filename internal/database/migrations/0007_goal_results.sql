@@ -0,0 +1,11 @@
+-- 週ごとの目標と達成状況を保存する
+CREATE TABLE IF NOT EXISTS goal_results (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    weekly_stats_id INTEGER NOT NULL,
+    metric TEXT NOT NULL, -- commits, active_days, merged_prs, repos, lines
+    target INTEGER NOT NULL,
+    actual INTEGER NOT NULL,
+    achieved INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (weekly_stats_id) REFERENCES weekly_stats(id),
+    UNIQUE(weekly_stats_id, metric)
+);
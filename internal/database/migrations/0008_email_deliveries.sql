@@ -0,0 +1,11 @@
+-- 週間レポートを送信済みの送信先を保存する（再実行時の二重送信を防ぐ）
+CREATE TABLE IF NOT EXISTS email_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT NOT NULL,
+    start_date TEXT NOT NULL,
+    recipient TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL, -- weekly/{username}/{start_date}/{recipient}
+    message_id TEXT NOT NULL DEFAULT '', -- 送信サービス上のID
+    delivered_at TEXT NOT NULL,
+    UNIQUE(idempotency_key)
+);
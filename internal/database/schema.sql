@@ -1,10 +1,16 @@
 CREATE TABLE IF NOT EXISTS weekly_stats (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
-    start_date TEXT NOT NULL UNIQUE,
+    username TEXT NOT NULL DEFAULT '',
+    start_date TEXT NOT NULL,
     end_date TEXT NOT NULL,
     total_commits INTEGER NOT NULL,
     active_days INTEGER NOT NULL,
-    created_at TEXT NOT NULL
+    created_at TEXT NOT NULL,
+    current_day_streak INTEGER NOT NULL DEFAULT 0,
+    longest_day_streak INTEGER NOT NULL DEFAULT 0,
+    current_week_streak INTEGER NOT NULL DEFAULT 0,
+    longest_week_streak INTEGER NOT NULL DEFAULT 0,
+    UNIQUE(username, start_date)
 );
 
 CREATE TABLE IF NOT EXISTS daily_commits (
@@ -25,12 +31,29 @@ CREATE TABLE IF NOT EXISTS hourly_activity (
     UNIQUE(weekly_stats_id, hour)
 );
 
+CREATE TABLE IF NOT EXISTS weekday_hourly_activity (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    weekly_stats_id INTEGER NOT NULL,
+    weekday INTEGER NOT NULL, -- 0=日曜日
+    hour INTEGER NOT NULL,
+    commits INTEGER NOT NULL,
+    FOREIGN KEY (weekly_stats_id) REFERENCES weekly_stats(id),
+    UNIQUE(weekly_stats_id, weekday, hour)
+);
+
 CREATE TABLE IF NOT EXISTS repo_details (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     weekly_stats_id INTEGER NOT NULL,
     repo_name TEXT NOT NULL,
     commits INTEGER NOT NULL,
     bar_width INTEGER NOT NULL,
+    additions INTEGER NOT NULL DEFAULT 0,
+    deletions INTEGER NOT NULL DEFAULT 0,
+    private BOOLEAN DEFAULT FALSE,
+    primary_language TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    pushed_at TEXT NOT NULL DEFAULT '',
+    languages TEXT NOT NULL DEFAULT '{}', -- 言語ごとの変更ファイル数（JSON）
     FOREIGN KEY (weekly_stats_id) REFERENCES weekly_stats(id),
     UNIQUE(weekly_stats_id, repo_name)
 );
@@ -41,6 +64,48 @@ CREATE TABLE IF NOT EXISTS language_commits (
     language TEXT NOT NULL,
     commits INTEGER NOT NULL,
     is_main BOOLEAN DEFAULT FALSE,
+    lines INTEGER NOT NULL DEFAULT 0,
+    commit_count INTEGER NOT NULL DEFAULT 0,
     FOREIGN KEY (weekly_stats_id) REFERENCES weekly_stats(id),
     UNIQUE(weekly_stats_id, language)
-);
\ No newline at end of file
+);
+
+CREATE TABLE IF NOT EXISTS commit_types (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    weekly_stats_id INTEGER NOT NULL,
+    repo_name TEXT NOT NULL,
+    commit_type TEXT NOT NULL, -- feat, fix などの Conventional Commits の種類（形式外は other）
+    commits INTEGER NOT NULL,
+    FOREIGN KEY (weekly_stats_id) REFERENCES weekly_stats(id),
+    UNIQUE(weekly_stats_id, repo_name, commit_type)
+);
+
+CREATE TABLE IF NOT EXISTS commit_scopes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    weekly_stats_id INTEGER NOT NULL,
+    scope TEXT NOT NULL,
+    commits INTEGER NOT NULL,
+    FOREIGN KEY (weekly_stats_id) REFERENCES weekly_stats(id),
+    UNIQUE(weekly_stats_id, scope)
+);
+CREATE TABLE IF NOT EXISTS goal_results (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    weekly_stats_id INTEGER NOT NULL,
+    metric TEXT NOT NULL, -- commits, active_days, merged_prs, repos, lines
+    target INTEGER NOT NULL,
+    actual INTEGER NOT NULL,
+    achieved INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (weekly_stats_id) REFERENCES weekly_stats(id),
+    UNIQUE(weekly_stats_id, metric)
+);
+
+CREATE TABLE IF NOT EXISTS email_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT NOT NULL,
+    start_date TEXT NOT NULL,
+    recipient TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL, -- weekly/{username}/{start_date}/{recipient}
+    message_id TEXT NOT NULL DEFAULT '', -- 送信サービス上のID
+    delivered_at TEXT NOT NULL,
+    UNIQUE(idempotency_key)
+);
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
 	"os"
+	"strings"
 )
 
 // JSONファイルを生成する関数
@@ -21,3 +23,130 @@ func GenerateJSONData(data *github.WeeklyComparison) error {
 
 	return os.WriteFile(fileName, file, 0644)
 }
+
+// チームレポートのJSONファイルを生成する関数
+func GenerateTeamJSONData(report *github.TeamReport) error {
+	fileName := fmt.Sprintf("%s-team.json", report.Team.EndDate.Format("2006-01-02"))
+
+	//JSON書き出し
+	file, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("JSON変換失敗: %w", err)
+	}
+
+	fmt.Println(fileName)
+
+	return os.WriteFile(fileName, file, 0644)
+}
+
+// Markdownファイルを生成する関数（週報として貼り付ける用）
+func GenerateMarkdownData(data *github.WeeklyComparison, lang i18n.Language) error {
+	fileName := fmt.Sprintf("%s.md", data.CurrentWeek.EndDate.Format("2006-01-02"))
+
+	fmt.Println(fileName)
+
+	return os.WriteFile(fileName, []byte(renderMarkdown(data, lang)), 0644)
+}
+
+// チームレポートのMarkdownファイルを生成する関数
+func GenerateTeamMarkdownData(report *github.TeamReport, lang i18n.Language) error {
+	fileName := fmt.Sprintf("%s-team.md", report.Team.EndDate.Format("2006-01-02"))
+
+	fmt.Println(fileName)
+
+	return os.WriteFile(fileName, []byte(renderTeamMarkdown(report, lang)), 0644)
+}
+
+// 週間レポートを Markdown に変換
+func renderMarkdown(data *github.WeeklyComparison, lang i18n.Language) string {
+	current := data.CurrentWeek
+	var b strings.Builder
+
+	b.WriteString(lang.T("markdown.title",
+		current.StartDate.Format("2006-01-02"), current.EndDate.Format("2006-01-02")) + "\n\n")
+	writeWeeklyMarkdown(&b, data, lang, 2)
+	return b.String()
+}
+
+// チームレポートを Markdown に変換（チーム集計のあとにメンバーごとの節を並べる）
+func renderTeamMarkdown(report *github.TeamReport, lang i18n.Language) string {
+	team := report.Team
+	var b strings.Builder
+
+	b.WriteString(lang.T("markdown.team_title",
+		team.StartDate.Format("2006-01-02"), team.EndDate.Format("2006-01-02")) + "\n\n")
+	b.WriteString(lang.T("markdown.total_commits", team.TotalCommits, team.CommitsDiff) + "\n")
+	b.WriteString(lang.T("markdown.active_members", team.ActiveMembers, len(team.Members)) + "\n")
+
+	if len(team.RepoDetails) > 0 {
+		b.WriteString("\n## " + lang.T("team.repos") + "\n\n")
+		for _, repo := range team.RepoDetails {
+			fmt.Fprintf(&b, "- %s: %d\n", repo.Name, repo.Count)
+		}
+	}
+
+	for _, member := range report.Members {
+		fmt.Fprintf(&b, "\n## %s\n\n", member.Username)
+		writeWeeklyMarkdown(&b, member.Comparison, lang, 3)
+	}
+	return b.String()
+}
+
+// 週間レポートの本文（コミット数・目標・ハイライト）を書き出す
+// level は目標・ハイライトの見出しのレベル（リポジトリの見出しはその1つ下）
+func writeWeeklyMarkdown(b *strings.Builder, data *github.WeeklyComparison, lang i18n.Language, level int) {
+	current := data.CurrentWeek
+	heading := strings.Repeat("#", level)
+
+	b.WriteString(lang.T("markdown.total_commits", current.TotalCommits, data.CommitsDiff) + "\n")
+	b.WriteString(lang.T("markdown.active_days", current.ActiveDays) + "\n")
+
+	if len(current.Goals) > 0 {
+		b.WriteString("\n" + heading + " " + lang.T("report.goals") + "\n\n")
+		for _, goal := range current.Goals {
+			mark := " "
+			if goal.Achieved {
+				mark = "x"
+			}
+			fmt.Fprintf(b, "- [%s] %s: %d / %d\n", mark, lang.Label("goal", goal.Metric), goal.Actual, goal.Target)
+		}
+		if len(data.GoalSummaries) > 0 {
+			b.WriteString("\n")
+		}
+		for _, summary := range data.GoalSummaries {
+			b.WriteString(lang.T("markdown.goal_hit_rate", lang.Label("goal", summary.Metric), summary.HitRate, summary.Achieved, summary.Weeks) + "\n")
+		}
+	}
+
+	if len(current.Highlights) == 0 {
+		return
+	}
+
+	b.WriteString("\n" + heading + " " + lang.T("report.highlights") + "\n")
+	for _, repo := range current.Highlights {
+		fmt.Fprintf(b, "\n%s# %s\n\n", heading, repo.Repo)
+		for _, item := range repo.Items {
+			label := markdownLinkText(item.Title)
+			if item.URL != "" {
+				label = fmt.Sprintf("[%s](%s)", label, item.URL)
+			}
+			fmt.Fprintf(b, "- %s %s\n", highlightIcons[item.Kind], label)
+		}
+	}
+}
+
+// リンクの記法を壊さないよう、タイトルの角括弧・丸括弧をエスケープする
+var markdownLinkEscaper = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`)
+
+// ハイライトのタイトルを Markdown のリストの1行に収まる形にする（最初の改行以降は捨てる）
+func markdownLinkText(title string) string {
+	title, _, _ = strings.Cut(title, "\n")
+	return markdownLinkEscaper.Replace(strings.TrimSpace(title))
+}
+
+// ハイライトの種類ごとのアイコン
+var highlightIcons = map[string]string{
+	github.HighlightRelease:     "🚀",
+	github.HighlightPullRequest: "🔀",
+	github.HighlightCommit:      "📝",
+}
@@ -0,0 +1,43 @@
+package document
+
+import (
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"strings"
+	"testing"
+	"time"
+)
+
+// テスト: ハイライトのタイトルに括弧や改行を含む場合もリンクを壊さない
+func TestRenderMarkdownHighlightTitle(t *testing.T) {
+	start := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+	data := &github.WeeklyComparison{
+		CurrentWeek: &github.WeeklyStats{
+			StartDate: start,
+			EndDate:   start.AddDate(0, 0, 6),
+			Highlights: []github.RepoHighlights{{Repo: "api", Items: []github.Highlight{
+				{
+					Kind:  github.HighlightPullRequest,
+					Title: "[WIP] fix(api): handle ] and (x)\nCloses #12",
+					URL:   "https://github.com/user/api/pull/34",
+				},
+				{Kind: github.HighlightCommit, Title: "feat: add [beta] flag"},
+			}}},
+		},
+	}
+
+	markdown := renderMarkdown(data, i18n.Default)
+
+	expected := []string{
+		`- 🔀 [\[WIP\] fix\(api\): handle \] and \(x\)](https://github.com/user/api/pull/34)` + "\n",
+		`- 📝 feat: add \[beta\] flag` + "\n",
+	}
+	for _, line := range expected {
+		if !strings.Contains(markdown, line) {
+			t.Errorf("expected %q in:\n%s", line, markdown)
+		}
+	}
+	if strings.Contains(markdown, "Closes #12") {
+		t.Errorf("title should be cut at the first newline:\n%s", markdown)
+	}
+}
@@ -0,0 +1,279 @@
+package email
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"github-weekly-log/internal/github"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"maps"
+	"math"
+	"slices"
+)
+
+// メール本文から cid: で参照するインライン画像
+type InlineImage struct {
+	ContentID   string // Content-ID（"<>" を含まない）
+	Filename    string // ファイル名
+	ContentType string // MIME タイプ
+	Data        []byte // 画像データ
+}
+
+// グラフの凡例（言語・リポジトリと色の対応）
+type ChartLegend struct {
+	Name  string // 言語名・リポジトリ名
+	Value int    // 値
+	Color string // "#rrggbb" 形式の色
+}
+
+// メールに埋め込むグラフ（データがないグラフは nil）
+type Charts struct {
+	Daily          *InlineImage  // 日別のコミット数（棒グラフ）
+	Hourly         *InlineImage  // 時間帯ごとのコミット数（棒グラフ）
+	Languages      *InlineImage  // 主要言語の割合（ドーナツグラフ）
+	LanguageLegend []ChartLegend // 主要言語の凡例
+	Repos          *InlineImage  // リポジトリ別のコミット数（横棒グラフ）
+	RepoLegend     []ChartLegend // リポジトリの凡例
+}
+
+// グラフの描画設定（高解像度ディスプレイ向けに表示サイズの2倍で描画する）
+const (
+	chartScale     = 2
+	chartWidth     = 550 * chartScale // 表示幅 550px
+	chartPadding   = 8 * chartScale
+	chartBarHeight = 14 * chartScale // 横棒グラフの1本の太さ
+	chartBarGap    = 10 * chartScale // 横棒グラフの間隔
+	chartDonutSize = 200 * chartScale
+	chartMaxRepos  = 8 // 横棒グラフに表示するリポジトリ数の上限
+)
+
+// グラフの色（メールの配色に合わせる）
+var (
+	chartBackground = color.RGBA{0x0d, 0x11, 0x16, 0xff}
+	chartGrid       = color.RGBA{0x30, 0x36, 0x3d, 0xff}
+	chartGreen      = color.RGBA{0x26, 0xa6, 0x41, 0xff}
+	chartBlue       = color.RGBA{0x30, 0x81, 0xf7, 0xff}
+)
+
+// 言語・リポジトリごとに使う色（順に割り当てる）
+var chartPalette = []color.RGBA{
+	{0x30, 0x81, 0xf7, 0xff},
+	{0x26, 0xa6, 0x41, 0xff},
+	{0xd2, 0x99, 0x22, 0xff},
+	{0xdb, 0x61, 0xa2, 0xff},
+	{0x89, 0x57, 0xe5, 0xff},
+	{0xf0, 0x88, 0x3e, 0xff},
+	{0x56, 0xd4, 0xdd, 0xff},
+	{0x91, 0x98, 0xa1, 0xff},
+}
+
+// 週間データからメールに埋め込むグラフを生成
+func RenderCharts(comparison github.WeeklyComparison) (*Charts, error) {
+	current := comparison.CurrentWeek
+	charts := &Charts{}
+
+	daily := make([]int, 0, len(current.DailyCommits))
+	for _, day := range current.DailyCommits {
+		daily = append(daily, day.Count)
+	}
+
+	var err error
+	if charts.Daily, err = renderBarChart("daily-chart", daily, 160*chartScale, chartGreen); err != nil {
+		return nil, err
+	}
+	if charts.Hourly, err = renderBarChart("hourly-chart", current.HourlyActivity[:], 100*chartScale, chartBlue); err != nil {
+		return nil, err
+	}
+
+	languages := sortedLegend(current.MainLanguagesBy(comparison.LanguageMetric))
+	if charts.Languages, err = renderDonutChart("language-chart", languages); err != nil {
+		return nil, err
+	}
+	if charts.Languages != nil {
+		charts.LanguageLegend = languages
+	}
+
+	var repos []ChartLegend
+	for i, repo := range current.RepoDetails {
+		if i >= chartMaxRepos {
+			break
+		}
+		repos = append(repos, ChartLegend{Name: repo.Name, Value: repo.Count, Color: hexColor(chartPalette[i%len(chartPalette)])})
+	}
+	if charts.Repos, err = renderHorizontalBarChart("repo-chart", repos); err != nil {
+		return nil, err
+	}
+	if charts.Repos != nil {
+		charts.RepoLegend = repos
+	}
+
+	return charts, nil
+}
+
+// 埋め込む画像の一覧（データがないグラフは含まない）
+func (c *Charts) Images() []InlineImage {
+	if c == nil {
+		return nil
+	}
+	var images []InlineImage
+	for _, image := range []*InlineImage{c.Daily, c.Hourly, c.Languages, c.Repos} {
+		if image != nil {
+			images = append(images, *image)
+		}
+	}
+	return images
+}
+
+// 値の大きい順（同数は名前順）に凡例を作成し、色を割り当てる
+func sortedLegend(values map[string]int) []ChartLegend {
+	names := slices.SortedFunc(maps.Keys(values), func(a, b string) int {
+		return cmp.Or(cmp.Compare(values[b], values[a]), cmp.Compare(a, b))
+	})
+	legend := make([]ChartLegend, 0, len(names))
+	for i, name := range names {
+		if values[name] <= 0 {
+			continue
+		}
+		legend = append(legend, ChartLegend{Name: name, Value: values[name], Color: hexColor(chartPalette[i%len(chartPalette)])})
+	}
+	return legend
+}
+
+// 縦棒グラフ（値がすべて0の場合は nil）
+func renderBarChart(contentID string, values []int, height int, bar color.RGBA) (*InlineImage, error) {
+	maxValue := slices.Max(append([]int{0}, values...))
+	if maxValue == 0 {
+		return nil, nil
+	}
+
+	img := newChartImage(chartWidth, height)
+	baseline := height - chartPadding
+	plotHeight := baseline - chartPadding
+	slot := float64(chartWidth-2*chartPadding) / float64(len(values))
+	barWidth := int(slot * 0.7)
+
+	// 目盛り線（最大値の 1/2 と基準線）
+	fillRect(img, chartPadding, chartPadding+plotHeight/2, chartWidth-chartPadding, chartPadding+plotHeight/2+1, chartGrid)
+	fillRect(img, chartPadding, baseline, chartWidth-chartPadding, baseline+chartScale, chartGrid)
+
+	for i, value := range values {
+		if value == 0 {
+			continue
+		}
+		x := chartPadding + int(slot*float64(i)+(slot-float64(barWidth))/2)
+		barHeight := max(int(math.Round(float64(plotHeight)*float64(value)/float64(maxValue))), chartScale)
+		fillRect(img, x, baseline-barHeight, x+barWidth, baseline, bar)
+	}
+
+	return encodeChart(contentID, img)
+}
+
+// 横棒グラフ（凡例の色で描画、データがない場合は nil）
+func renderHorizontalBarChart(contentID string, items []ChartLegend) (*InlineImage, error) {
+	maxValue := 0
+	for _, item := range items {
+		maxValue = max(maxValue, item.Value)
+	}
+	if maxValue == 0 {
+		return nil, nil
+	}
+
+	height := 2*chartPadding + len(items)*chartBarHeight + (len(items)-1)*chartBarGap
+	img := newChartImage(chartWidth, height)
+	plotWidth := chartWidth - 2*chartPadding
+
+	for i, item := range items {
+		y := chartPadding + i*(chartBarHeight+chartBarGap)
+		fillRect(img, chartPadding, y, chartWidth-chartPadding, y+chartBarHeight, chartGrid)
+		barWidth := max(int(math.Round(float64(plotWidth)*float64(item.Value)/float64(maxValue))), chartScale)
+		fillRect(img, chartPadding, y, chartPadding+barWidth, y+chartBarHeight, parseHexColor(item.Color))
+	}
+
+	return encodeChart(contentID, img)
+}
+
+// ドーナツグラフ（12時の位置から時計回り、データがない場合は nil）
+func renderDonutChart(contentID string, items []ChartLegend) (*InlineImage, error) {
+	total := 0
+	for _, item := range items {
+		total += item.Value
+	}
+	if total == 0 {
+		return nil, nil
+	}
+
+	// 各要素の終了位置（0〜1 の割合）
+	ends := make([]float64, len(items))
+	sum := 0
+	for i, item := range items {
+		sum += item.Value
+		ends[i] = float64(sum) / float64(total)
+	}
+
+	img := newChartImage(chartDonutSize, chartDonutSize)
+	center := float64(chartDonutSize) / 2
+	outer := center - float64(chartPadding)
+	inner := outer * 0.6
+
+	for y := 0; y < chartDonutSize; y++ {
+		for x := 0; x < chartDonutSize; x++ {
+			dx, dy := float64(x)+0.5-center, float64(y)+0.5-center
+			distance := math.Hypot(dx, dy)
+			if distance > outer || distance < inner {
+				continue
+			}
+			position := math.Atan2(dx, -dy) / (2 * math.Pi)
+			if position < 0 {
+				position++
+			}
+			index, _ := slices.BinarySearch(ends, position)
+			index = min(index, len(items)-1)
+			img.SetRGBA(x, y, parseHexColor(items[index].Color))
+		}
+	}
+
+	return encodeChart(contentID, img)
+}
+
+// 背景色で塗りつぶした画像を生成
+func newChartImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{chartBackground}, image.Point{}, draw.Src)
+	return img
+}
+
+// 矩形を塗りつぶす
+func fillRect(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	draw.Draw(img, image.Rect(x0, y0, x1, y1), &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+// PNG に変換してインライン画像にする
+func encodeChart(contentID string, img image.Image) (*InlineImage, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("グラフの生成に失敗しました (%s): %w", contentID, err)
+	}
+	return &InlineImage{
+		ContentID:   contentID,
+		Filename:    contentID + ".png",
+		ContentType: "image/png",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// 色を "#rrggbb" 形式に変換
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// "#rrggbb" 形式の色を変換（不正な値は灰色）
+func parseHexColor(s string) color.RGBA {
+	var r, g, b uint8
+	if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return chartGrid
+	}
+	return color.RGBA{r, g, b, 0xff}
+}
@@ -0,0 +1,116 @@
+package email
+
+import (
+	"bytes"
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+)
+
+// PNG を読み込む
+func decodeChart(t *testing.T, chart *InlineImage) image.Image {
+	t.Helper()
+	if chart == nil {
+		t.Fatalf("chart is nil")
+	}
+	if chart.ContentType != "image/png" || chart.Filename != chart.ContentID+".png" {
+		t.Errorf("unexpected metadata: %+v", chart)
+	}
+	img, err := png.Decode(bytes.NewReader(chart.Data))
+	if err != nil {
+		t.Fatalf("decode %s: %v", chart.ContentID, err)
+	}
+	return img
+}
+
+// 指定した位置の色を比較
+func assertPixel(t *testing.T, img image.Image, x, y int, want color.RGBA) {
+	t.Helper()
+	if got := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA); got != want {
+		t.Errorf("pixel (%d, %d): expected %v, got %v", x, y, want, got)
+	}
+}
+
+// テスト: 週間データからのグラフ生成
+func TestRenderCharts(t *testing.T) {
+	comparison := newTextTestComparison()
+	comparison.CurrentWeek.HourlyActivity[9] = 4
+	comparison.CurrentWeek.HourlyActivity[22] = 2
+
+	charts, err := RenderCharts(comparison)
+	if err != nil {
+		t.Fatalf("RenderCharts: %v", err)
+	}
+
+	// 日別: 最大値（6日目の15件）の棒は上端まで、0件の日は背景のまま
+	daily := decodeChart(t, charts.Daily)
+	if size := daily.Bounds().Size(); size.X != chartWidth || size.Y != 160*chartScale {
+		t.Errorf("daily size: got %v", size)
+	}
+	slot := (chartWidth - 2*chartPadding) / 7
+	assertPixel(t, daily, chartPadding+slot*5+slot/2, chartPadding+1, chartGreen)
+	assertPixel(t, daily, chartPadding+slot*0+slot/2, chartPadding+1, chartBackground)
+
+	hourly := decodeChart(t, charts.Hourly)
+	if size := hourly.Bounds().Size(); size.X != chartWidth || size.Y != 100*chartScale {
+		t.Errorf("hourly size: got %v", size)
+	}
+
+	// 言語: 多い順に色を割り当て、12時の位置は先頭の言語の色
+	languages := decodeChart(t, charts.Languages)
+	if size := languages.Bounds().Size(); size.X != chartDonutSize || size.Y != chartDonutSize {
+		t.Errorf("language size: got %v", size)
+	}
+	if len(charts.LanguageLegend) != 3 || charts.LanguageLegend[0].Name != "Go" || charts.LanguageLegend[0].Color != hexColor(chartPalette[0]) {
+		t.Errorf("language legend: got %+v", charts.LanguageLegend)
+	}
+	assertPixel(t, languages, chartDonutSize/2, chartPadding+chartScale, chartPalette[0])
+	assertPixel(t, languages, chartDonutSize/2, chartDonutSize/2, chartBackground)
+
+	// リポジトリ: 最大のリポジトリの棒は右端まで
+	repos := decodeChart(t, charts.Repos)
+	if len(charts.RepoLegend) != 3 || charts.RepoLegend[1].Name != "go-utils" || charts.RepoLegend[1].Value != 12 {
+		t.Errorf("repo legend: got %+v", charts.RepoLegend)
+	}
+	assertPixel(t, repos, chartWidth-chartPadding-1, chartPadding+1, chartPalette[0])
+	assertPixel(t, repos, chartWidth-chartPadding-1, chartPadding+chartBarHeight+chartBarGap+1, chartGrid)
+
+	images := charts.Images()
+	ids := []string{}
+	for _, image := range images {
+		ids = append(ids, image.ContentID)
+	}
+	if len(ids) != 4 || ids[0] != "daily-chart" || ids[1] != "hourly-chart" || ids[2] != "language-chart" || ids[3] != "repo-chart" {
+		t.Errorf("Images: got %v", ids)
+	}
+}
+
+// テスト: データがないグラフは生成しない
+func TestRenderChartsEmpty(t *testing.T) {
+	charts, err := RenderCharts(github.WeeklyComparison{CurrentWeek: &github.WeeklyStats{
+		DailyCommits: []github.DailyCommit{{Count: 0}, {Count: 0}},
+	}})
+	if err != nil {
+		t.Fatalf("RenderCharts: %v", err)
+	}
+	if charts.Daily != nil || charts.Hourly != nil || charts.Languages != nil || charts.Repos != nil {
+		t.Errorf("expected no charts, got %+v", charts)
+	}
+	if len(charts.Images()) != 0 || len((*Charts)(nil).Images()) != 0 {
+		t.Errorf("expected no images")
+	}
+
+	// グラフが1つもない場合は見出しも表示しない
+	html, err := LoadTemplateWithCharts(SampleWeeklyComparison(time.Now()), charts)
+	if err != nil {
+		t.Fatalf("LoadTemplateWithCharts: %v", err)
+	}
+	if strings.Contains(html, ">"+i18n.Default.T("report.charts")+"</div>") {
+		t.Errorf("expected no charts heading")
+	}
+}
@@ -0,0 +1,121 @@
+package email
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ファイルへの書き出し形式
+const (
+	FileFormatEML     = "eml"     // 1通ずつ .eml ファイルに書き出す（既定）
+	FileFormatMaildir = "maildir" // Maildir（tmp / new / cur）の new に書き出す
+)
+
+// ファイルへの書き出しの設定
+type FileConfig struct {
+	Dir    string // 書き出し先のディレクトリ（ない場合は作成する）
+	Format string // 書き出し形式（空の場合は FileFormatEML）
+}
+
+// 送信せずに MIME 形式のメールをディレクトリへ書き出す（ローカルでの確認・テスト用）
+// SMTP で送信する場合と同じ内容を書き出すため、Bcc はヘッダーに含まれない
+type FileSender struct {
+	cfg FileConfig
+}
+
+// ファイルへの書き出しの送信方法を生成
+func NewFileSender(cfg FileConfig) (*FileSender, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("メールの書き出し先のディレクトリが未設定です")
+	}
+
+	cfg.Format = strings.ToLower(cfg.Format)
+	var dirs []string
+	switch cfg.Format {
+	case "", FileFormatEML:
+		cfg.Format = FileFormatEML
+		dirs = []string{cfg.Dir}
+	case FileFormatMaildir:
+		dirs = []string{filepath.Join(cfg.Dir, "tmp"), filepath.Join(cfg.Dir, "new"), filepath.Join(cfg.Dir, "cur")}
+	default:
+		return nil, fmt.Errorf("未対応の書き出し形式です: %q", cfg.Format)
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("書き出し先のディレクトリを作成できません: %w", err)
+		}
+	}
+
+	return &FileSender{cfg: cfg}, nil
+}
+
+// メールをファイルに書き出し、書き出したファイルのパスを返す
+func (s *FileSender) Send(ctx context.Context, msg Message) (string, error) {
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return "", permanent(fmt.Errorf("送信元のアドレスが不正です: %w", err))
+	}
+	if len(msg.To) == 0 {
+		return "", permanent(fmt.Errorf("送信先が未設定です"))
+	}
+
+	now := time.Now()
+	body, err := buildMIMEMessage(msg, newMessageID(from.Address, msg.IdempotencyKey), now)
+	if err != nil {
+		return "", err
+	}
+
+	// 書き込み途中のファイルを読まれないよう、一時ファイルに書き込んでから移動する
+	tmpDir, dir, name := s.cfg.Dir, s.cfg.Dir, fileName(now)+".eml"
+	if s.cfg.Format == FileFormatMaildir {
+		tmpDir, dir, name = filepath.Join(s.cfg.Dir, "tmp"), filepath.Join(s.cfg.Dir, "new"), maildirName(now)
+	}
+	tmp, err := os.CreateTemp(tmpDir, ".mail-*")
+	if err != nil {
+		return "", fmt.Errorf("メールを書き出せません: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("メールを書き出せません: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("メールを書き出せません: %w", err)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("メールを書き出せません: %w", err)
+	}
+	return path, nil
+}
+
+// 書き出すファイルの名前（"20260221-090000-1a2b3c4d"、日時順に並ぶ）
+func fileName(now time.Time) string {
+	return now.Format("20060102-150405") + "-" + randomHex(4)
+}
+
+// Maildir のファイル名（"{秒}.{一意な値}.{ホスト名}"）
+func maildirName(now time.Time) string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "localhost"
+	}
+	// Maildir のファイル名に使えない文字は置き換える
+	host = strings.NewReplacer("/", `\057`, ":", `\072`).Replace(host)
+	return fmt.Sprintf("%d.M%dR%s.%s", now.Unix(), now.Nanosecond()/1000, randomHex(4), host)
+}
+
+// n バイトの乱数の16進数表記
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
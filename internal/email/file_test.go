@@ -0,0 +1,170 @@
+package email
+
+import (
+	"context"
+	"maps"
+	"mime"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// テスト: MIME 形式のメールを .eml ファイルに書き出す
+func TestFileSender(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	sender, err := NewFileSender(FileConfig{Dir: dir})
+	if err != nil {
+		t.Fatalf("NewFileSender: %v", err)
+	}
+
+	path, err := sender.Send(context.Background(), Message{
+		From:           "お疲れ様委員会 <report@example.com>",
+		To:             []string{"上司 <boss@example.com>"},
+		Cc:             []string{"lead@example.com"},
+		Bcc:            []string{"hidden@example.com"},
+		Subject:        "週間コミットレポート (2026/02/21)",
+		HTML:           "<p>42 コミット</p>",
+		Text:           "総コミット数: 42",
+		IdempotencyKey: "weekly/alice/2026-02-14/boss@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if filepath.Dir(path) != dir || !strings.HasSuffix(path, ".eml") {
+		t.Errorf("path: got %q", path)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected only the written mail, got %d entries", len(entries))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read mail: %v", err)
+	}
+	if strings.Contains(string(data), "hidden@example.com") {
+		t.Errorf("expected Bcc to be excluded from the headers")
+	}
+
+	msg, bodies := parseReceivedMail(t, data)
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "週間コミットレポート (2026/02/21)" {
+		t.Errorf("Subject: got %q", subject)
+	}
+	to, err := msg.Header.AddressList("To")
+	if err != nil || to[0].Name != "上司" || to[0].Address != "boss@example.com" {
+		t.Errorf("To: got %v (%v)", to, err)
+	}
+	if msg.Header.Get("Cc") != "<lead@example.com>" {
+		t.Errorf("Cc: got %q", msg.Header.Get("Cc"))
+	}
+	if bodies["text/plain"] != "総コミット数: 42" || bodies["text/html"] != "<p>42 コミット</p>" {
+		t.Errorf("bodies: got %q", bodies)
+	}
+
+	// 冪等キーが同じ場合は Message-ID も同じになる
+	again, err := sender.Send(context.Background(), Message{From: "report@example.com", To: []string{"boss@example.com"}, IdempotencyKey: "weekly/alice/2026-02-14/boss@example.com"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	againData, _ := os.ReadFile(again)
+	againMsg, _ := parseReceivedMail(t, againData)
+	if againMsg.Header.Get("Message-ID") != msg.Header.Get("Message-ID") {
+		t.Errorf("expected the same Message-ID, got %q and %q", againMsg.Header.Get("Message-ID"), msg.Header.Get("Message-ID"))
+	}
+}
+
+// テスト: Maildir 形式では new に書き出し、tmp には残さない
+func TestFileSenderMaildir(t *testing.T) {
+	dir := t.TempDir()
+	sender, err := NewFileSender(FileConfig{Dir: dir, Format: "Maildir"})
+	if err != nil {
+		t.Fatalf("NewFileSender: %v", err)
+	}
+
+	path, err := sender.Send(context.Background(), Message{From: "report@example.com", To: []string{"me@example.com"}, Subject: "report", HTML: "<p>report</p>"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if filepath.Dir(path) != filepath.Join(dir, "new") {
+		t.Errorf("path: got %q", path)
+	}
+	for name, expected := range map[string]int{"tmp": 0, "new": 1, "cur": 0} {
+		if entries, err := os.ReadDir(filepath.Join(dir, name)); err != nil || len(entries) != expected {
+			t.Errorf("%s: expected %d entries, got %d (%v)", name, expected, len(entries), err)
+		}
+	}
+}
+
+// テスト: 設定や送信先が不正な場合はエラー
+func TestFileSenderError(t *testing.T) {
+	for _, cfg := range []FileConfig{{}, {Dir: t.TempDir(), Format: "mbox"}} {
+		if _, err := NewFileSender(cfg); err == nil {
+			t.Errorf("%+v: expected error", cfg)
+		}
+	}
+
+	sender, err := NewFileSender(FileConfig{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewFileSender: %v", err)
+	}
+	if _, err := sender.Send(context.Background(), Message{From: "report@example.com"}); err == nil {
+		t.Errorf("expected error for missing recipients")
+	}
+}
+
+// テスト: 週間レポートのテスト送信をファイルに書き出して確認する
+func TestWeeklyMailSendToFile(t *testing.T) {
+	t.Setenv("RESEND_EMAIL_DOMAIN", "report@example.com")
+	t.Setenv("RESEND_EMAIL_DOMAIN_DEV", "dev@example.com")
+	t.Setenv("TEST_RESEND_EMAIL_TO", "me@example.com")
+	dir := t.TempDir()
+	sender, err := NewSender(Config{Provider: ProviderFile, File: FileConfig{Dir: dir}})
+	if err != nil {
+		t.Fatalf("NewSender: %v", err)
+	}
+
+	if err := TestWeeklyMailSend(sender); err != nil {
+		t.Fatalf("TestWeeklyMailSend: %v", err)
+	}
+	if err := TestSend(sender); err != nil {
+		t.Fatalf("TestSend: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected 2 mails, got %d (%v)", len(entries), err)
+	}
+	// 件名ごとに書き出したメールを読み込む（同じ秒に書き出した場合は順序が決まらない）
+	subjects := make(map[string]map[string]string)
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("read mail: %v", err)
+		}
+		msg, bodies := parseReceivedMail(t, data)
+		subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		if to := msg.Header.Get("To"); to != "<me@example.com>" {
+			t.Errorf("%s To: got %q", subject, to)
+		}
+		if strings.HasPrefix(subject, "[テスト]") {
+			if from, err := msg.Header.AddressList("From"); err != nil || from[0].Name != "[テスト]お疲れ様委員会" || from[0].Address != "dev@example.com" {
+				t.Errorf("From: got %v (%v)", from, err)
+			}
+		}
+		subjects[subject] = bodies
+	}
+
+	weekly, exists := subjects["[テスト]週間コミットレポート ("+time.Now().Format("2006/01/02")+")"]
+	if !exists {
+		t.Fatalf("expected weekly test mail, got %v", slices.Collect(maps.Keys(subjects)))
+	}
+	if !strings.Contains(weekly["text/html"], "awesome-project") || len(weekly) < 3 {
+		t.Errorf("expected rendered report with inline charts, got %d parts", len(weekly))
+	}
+	if _, exists := subjects["【Test】Weekly Log System Connection Check"]; !exists {
+		t.Errorf("expected connection check mail, got %v", slices.Collect(maps.Keys(subjects)))
+	}
+}
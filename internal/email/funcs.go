@@ -0,0 +1,89 @@
+package email
+
+import (
+	"github-weekly-log/internal/github"
+	"html/template"
+	"math"
+	"strings"
+)
+
+// テンプレートから使う関数
+var templateFuncs = template.FuncMap{
+	"heatLevel": heatLevel,
+	"heatColor": heatColor,
+	"signed":    formatDiff,
+	"percent":   percent,
+	"barWidth":  barWidth,
+	"sparkline": sparkline,
+}
+
+// 色の濃さごとの背景色（0 はコミットなし）
+var heatColors = []string{"#161b21", "#0e4429", "#006d32", "#26a641", "#39d353"}
+
+// 1日のコミット数から固定の閾値で色の濃さ（0-4）を決める（ヒートマップは最大値に対する割合で決める）
+func heatLevel(count int) int {
+	switch {
+	case count <= 0:
+		return 0
+	case count <= 3:
+		return 1
+	case count <= 9:
+		return 2
+	case count <= 19:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// 色の濃さに対応する背景色
+func heatColor(level int) string {
+	return heatColors[min(max(level, 0), len(heatColors)-1)]
+}
+
+// 全体に対する割合（%、四捨五入。全体が0の場合は0）
+func percent(part, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return int(math.Round(float64(part) * 100 / float64(total)))
+}
+
+// 棒グラフの幅（最大値に対する %、0〜100 に収める）
+func barWidth(value, maxValue int) int {
+	return min(max(percent(value, maxValue), 0), 100)
+}
+
+// スパークラインの文字（低い順）
+var sparklineChars = []rune("▁▂▃▄▅▆▇█")
+
+// 値の推移を1行の文字列で表す（日別・時間帯ごとのコミット数に対応）
+func sparkline(values any) string {
+	var counts []int
+	switch v := values.(type) {
+	case []int:
+		counts = v
+	case [24]int:
+		counts = v[:]
+	case []github.DailyCommit:
+		for _, day := range v {
+			counts = append(counts, day.Count)
+		}
+	default:
+		return ""
+	}
+
+	maxValue := 0
+	for _, count := range counts {
+		maxValue = max(maxValue, count)
+	}
+	var b strings.Builder
+	for _, count := range counts {
+		index := 0
+		if maxValue > 0 {
+			index = count * (len(sparklineChars) - 1) / maxValue
+		}
+		b.WriteRune(sparklineChars[max(index, 0)])
+	}
+	return b.String()
+}
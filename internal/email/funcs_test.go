@@ -0,0 +1,51 @@
+package email
+
+import (
+	"github-weekly-log/internal/github"
+	"testing"
+)
+
+// テスト: コミット数から色の濃さを決める
+func TestHeatLevel(t *testing.T) {
+	cases := map[int]int{-1: 0, 0: 0, 1: 1, 3: 1, 4: 2, 9: 2, 10: 3, 19: 3, 20: 4, 100: 4}
+	for count, want := range cases {
+		if got := heatLevel(count); got != want {
+			t.Errorf("heatLevel(%d): expected %d, got %d", count, want, got)
+		}
+	}
+	if heatColor(0) != "#161b21" || heatColor(4) != "#39d353" || heatColor(9) != "#39d353" {
+		t.Errorf("heatColor: unexpected colors")
+	}
+}
+
+// テスト: 数値の表示
+func TestNumberFuncs(t *testing.T) {
+	if got := formatDiff(12); got != "+12" {
+		t.Errorf("signed(12): got %q", got)
+	}
+	if got := formatDiff(-3); got != "-3" {
+		t.Errorf("signed(-3): got %q", got)
+	}
+	if got := percent(1, 3); got != 33 {
+		t.Errorf("percent(1, 3): got %d", got)
+	}
+	if got := percent(1, 0); got != 0 {
+		t.Errorf("percent(1, 0): got %d", got)
+	}
+	if got := barWidth(42, 30); got != 100 {
+		t.Errorf("barWidth(42, 30): got %d", got)
+	}
+}
+
+// テスト: スパークライン
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]int{0, 7, 14}); got != "▁▄█" {
+		t.Errorf("sparkline: got %q", got)
+	}
+	if got := sparkline([]github.DailyCommit{{Count: 0}, {Count: 0}}); got != "▁▁" {
+		t.Errorf("sparkline of zero days: got %q", got)
+	}
+	if got := sparkline("x"); got != "" {
+		t.Errorf("sparkline of unsupported type: got %q", got)
+	}
+}
@@ -0,0 +1,176 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Message-ID を生成（ドメインは送信元アドレスから取得）
+// 冪等キーがある場合はキーから生成し、再送しても同じ Message-ID にする
+func newMessageID(from string, idempotencyKey string) string {
+	domain := "localhost"
+	if _, host, ok := strings.Cut(from, "@"); ok && host != "" {
+		domain = host
+	}
+	buf := make([]byte, 16)
+	if idempotencyKey != "" {
+		sum := sha256.Sum256([]byte(idempotencyKey))
+		copy(buf, sum[:])
+	} else {
+		rand.Read(buf)
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(buf), domain)
+}
+
+// アドレスのリストをヘッダー用に変換（名前は RFC 2047 でエンコード）
+func formatAddressList(addresses []string) (string, error) {
+	formatted := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		addr, err := mail.ParseAddress(address)
+		if err != nil {
+			return "", permanent(fmt.Errorf("アドレスが不正です (%s): %w", address, err))
+		}
+		formatted = append(formatted, addr.String())
+	}
+	return strings.Join(formatted, ", "), nil
+}
+
+// メールを MIME 形式（multipart/alternative）に変換
+func buildMIMEMessage(msg Message, messageID string, date time.Time) ([]byte, error) {
+	from, err := formatAddressList([]string{msg.From})
+	if err != nil {
+		return nil, err
+	}
+	to, err := formatAddressList(msg.To)
+	if err != nil {
+		return nil, err
+	}
+
+	cc := ""
+	if len(msg.Cc) > 0 {
+		if cc, err = formatAddressList(msg.Cc); err != nil {
+			return nil, err
+		}
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	// 代替パートは優先度の低い順に並べる（テキスト → HTML）
+	if msg.Text != "" {
+		if err := writeQuotedPrintablePart(parts, "text/plain; charset=UTF-8", msg.Text); err != nil {
+			return nil, err
+		}
+	}
+	if len(msg.Inline) == 0 {
+		if err := writeQuotedPrintablePart(parts, "text/html; charset=UTF-8", msg.HTML); err != nil {
+			return nil, err
+		}
+	} else if err := writeRelatedPart(parts, msg.HTML, msg.Inline); err != nil {
+		return nil, err
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	headers := []struct{ key, value string }{
+		{"From", from},
+		{"To", to},
+		{"Cc", cc},
+		{"Subject", mime.BEncoding.Encode("UTF-8", msg.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", messageID},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": parts.Boundary()})},
+	}
+	for _, header := range headers {
+		if header.value == "" {
+			continue
+		}
+		fmt.Fprintf(&buf, "%s: %s\r\n", header.key, header.value)
+	}
+	buf.WriteString("\r\n")
+	buf.Write(body.Bytes())
+
+	return buf.Bytes(), nil
+}
+
+// HTML とインライン画像をまとめた multipart/related パートを追加
+func writeRelatedPart(parts *multipart.Writer, html string, images []InlineImage) error {
+	var related bytes.Buffer
+	relatedParts := multipart.NewWriter(&related)
+
+	if err := writeQuotedPrintablePart(relatedParts, "text/html; charset=UTF-8", html); err != nil {
+		return err
+	}
+	for _, image := range images {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", mime.FormatMediaType(image.ContentType, map[string]string{"name": image.Filename}))
+		header.Set("Content-Transfer-Encoding", "base64")
+		header.Set("Content-ID", "<"+image.ContentID+">")
+		header.Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": image.Filename}))
+
+		part, err := relatedParts.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if err := writeBase64Lines(part, image.Data); err != nil {
+			return err
+		}
+	}
+	if err := relatedParts.Close(); err != nil {
+		return err
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType("multipart/related", map[string]string{"type": "text/html", "boundary": relatedParts.Boundary()}))
+	part, err := parts.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = part.Write(related.Bytes())
+	return err
+}
+
+// base64 でエンコードし、76文字ごとに改行して書き出す
+func writeBase64Lines(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		n := min(len(encoded), 76)
+		if _, err := io.WriteString(w, encoded[:n]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[n:]
+	}
+	return nil
+}
+
+// quoted-printable でエンコードしたパートを追加
+func writeQuotedPrintablePart(parts *multipart.Writer, contentType string, content string) error {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	part, err := parts.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	w := quotedprintable.NewWriter(part)
+	if _, err := w.Write([]byte(content)); err != nil {
+		return err
+	}
+	return w.Close()
+}
@@ -0,0 +1,227 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// テンプレートの変更を確認する間隔
+const previewWatchInterval = 500 * time.Millisecond
+
+// 週間レポートをブラウザで確認するためのプレビュー
+// HTML はリクエストごとに描画するため、テンプレートの変更がそのまま反映される
+type Preview struct {
+	comparison github.WeeklyComparison
+	charts     *Charts
+	recipient  Recipient // 表示する言語を指定した送信先
+	watchDir   string    // 変更を監視するテンプレートのディレクトリ（空の場合は監視しない）
+	watchState string    // 最後に確認した watchDir の状態
+
+	mu      sync.Mutex
+	changed chan struct{} // テンプレートが変更されると close して作り直す
+}
+
+// プレビューを生成（watchDir のファイルが変更されるとブラウザを再読み込みする）
+func NewPreview(comparison github.WeeklyComparison, lang i18n.Language, watchDir string) (*Preview, error) {
+	charts, err := RenderCharts(comparison)
+	if err != nil {
+		return nil, err
+	}
+	return &Preview{
+		comparison: comparison,
+		charts:     charts,
+		recipient:  Recipient{Language: lang},
+		watchDir:   watchDir,
+		watchState: templateDirState(watchDir),
+		changed:    make(chan struct{}),
+	}, nil
+}
+
+// テンプレートの変更を ctx が終了するまで監視する
+func (p *Preview) Watch(ctx context.Context) {
+	if p.watchDir == "" {
+		return
+	}
+	ticker := time.NewTicker(previewWatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			state := templateDirState(p.watchDir)
+			if state == p.watchState {
+				continue
+			}
+			p.watchState = state
+			i18n.Println("console.preview_changed")
+			p.mu.Lock()
+			close(p.changed)
+			p.changed = make(chan struct{})
+			p.mu.Unlock()
+		}
+	}
+}
+
+// ディレクトリ内のファイルの更新日時とサイズをまとめた文字列
+func templateDirState(dir string) string {
+	var b strings.Builder
+	filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil {
+			fmt.Fprintf(&b, "%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+		}
+		return nil
+	})
+	return b.String()
+}
+
+// プレビューの HTTP ハンドラー
+//
+//	/          HTML・テキストを切り替えて表示するページ
+//	/html      メールの HTML（cid: の画像は /cid/ から読み込む）
+//	/text      メールのテキスト版
+//	/cid/{id}  グラフ画像
+//	/events    テンプレートの変更を通知する（Server-Sent Events）
+func (p *Preview) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", p.handleIndex)
+	mux.HandleFunc("GET /html", p.handleHTML)
+	mux.HandleFunc("GET /text", p.handleText)
+	mux.HandleFunc("GET /cid/{id}", p.handleImage)
+	mux.HandleFunc("GET /events", p.handleEvents)
+	return mux
+}
+
+func (p *Preview) handleIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, previewPage)
+}
+
+func (p *Preview) handleHTML(w http.ResponseWriter, r *http.Request) {
+	html, err := LoadRecipientTemplate(p.comparison, p.charts, p.recipient)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, strings.ReplaceAll(html, `src="cid:`, `src="/cid/`))
+}
+
+func (p *Preview) handleText(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprint(w, RenderRecipientText(p.comparison, p.recipient))
+}
+
+func (p *Preview) handleImage(w http.ResponseWriter, r *http.Request) {
+	for _, image := range p.charts.Images() {
+		if image.ContentID == r.PathValue("id") {
+			w.Header().Set("Content-Type", image.ContentType)
+			w.Write(image.Data)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+func (p *Preview) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	for {
+		p.mu.Lock()
+		changed := p.changed
+		p.mu.Unlock()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-changed:
+			fmt.Fprint(w, "event: reload\ndata: {}\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// プレビューのページ（HTML は iframe で表示し、変更を受け取ると再読み込みする）
+const previewPage = `<!doctype html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<title>Weekly Report Preview</title>
+<style>
+  body { margin: 0; background: #010409; color: #e1e8ee; font-family: Helvetica, Arial, sans-serif; }
+  nav { display: flex; gap: 8px; padding: 8px 16px; border-bottom: 1px solid #3d444d; }
+  nav button { background: none; border: 1px solid #3d444d; border-radius: 6px; color: #9198a1; padding: 4px 12px; cursor: pointer; }
+  nav button.active { color: #e1e8ee; border-color: #3081f7; }
+  iframe { display: block; width: 100%; height: calc(100vh - 45px); border: 0; }
+  pre { margin: 0; padding: 16px; white-space: pre; font-size: 13px; }
+  .hidden { display: none; }
+</style>
+</head>
+<body>
+<nav>
+  <button id="tab-html" class="active" onclick="show('html')">HTML</button>
+  <button id="tab-text" onclick="show('text')">text/plain</button>
+</nav>
+<iframe id="html" src="/html"></iframe>
+<pre id="text" class="hidden"></pre>
+<script>
+  function show(name) {
+    for (const id of ["html", "text"]) {
+      document.getElementById(id).classList.toggle("hidden", id !== name);
+      document.getElementById("tab-" + id).classList.toggle("active", id === name);
+    }
+    location.hash = name;
+  }
+  function loadText() {
+    fetch("/text").then((res) => res.text()).then((text) => { document.getElementById("text").textContent = text; });
+  }
+  new EventSource("/events").addEventListener("reload", () => {
+    document.getElementById("html").contentWindow.location.reload();
+    loadText();
+  });
+  loadText();
+  if (location.hash === "#text") show("text");
+</script>
+</body>
+</html>
+`
+
+// 指定したアドレスでプレビューを配信する（ctx が終了すると停止）
+func (p *Preview) ListenAndServe(ctx context.Context, addr string) error {
+	server := &http.Server{Addr: addr, Handler: p.Handler()}
+	go p.Watch(ctx)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	i18n.Println("console.preview_url", addr)
+	if p.watchDir != "" {
+		i18n.Println("console.preview_watching", p.watchDir)
+	}
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"github-weekly-log/internal/i18n"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// テスト用にプレビューのサーバーを起動
+func startTestPreview(t *testing.T, watchDir string) *httptest.Server {
+	t.Helper()
+	preview, err := NewPreview(SampleWeeklyComparison(time.Now()), i18n.Japanese, watchDir)
+	if err != nil {
+		t.Fatalf("NewPreview: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go preview.Watch(ctx)
+	server := httptest.NewServer(preview.Handler())
+	t.Cleanup(func() {
+		cancel()
+		server.Close()
+	})
+	return server
+}
+
+// GET してステータスと本文を返す
+func getPreview(t *testing.T, url string) (int, string) {
+	t.Helper()
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	return res.StatusCode, string(body)
+}
+
+// テスト: HTML・テキスト・画像の配信
+func TestPreviewHandler(t *testing.T) {
+	server := startTestPreview(t, "")
+
+	if status, body := getPreview(t, server.URL+"/"); status != http.StatusOK || !strings.Contains(body, `src="/html"`) {
+		t.Errorf("index: got %d", status)
+	}
+
+	status, html := getPreview(t, server.URL+"/html")
+	if status != http.StatusOK || strings.Contains(html, "cid:") || !strings.Contains(html, `src="/cid/daily-chart"`) {
+		t.Errorf("html: expected images from /cid/, got %d", status)
+	}
+
+	if status, text := getPreview(t, server.URL+"/text"); status != http.StatusOK || !strings.Contains(text, "週間コミットレポート") {
+		t.Errorf("text: got %d %q", status, text)
+	}
+
+	if status, image := getPreview(t, server.URL+"/cid/daily-chart"); status != http.StatusOK || !strings.HasPrefix(image, "\x89PNG") {
+		t.Errorf("image: got %d", status)
+	}
+	if status, _ := getPreview(t, server.URL+"/cid/unknown"); status != http.StatusNotFound {
+		t.Errorf("unknown image: expected 404, got %d", status)
+	}
+}
+
+// テスト: テンプレートを変更すると再読み込みを通知し、変更後のテンプレートで描画する
+func TestPreviewReload(t *testing.T) {
+	dir := setTestTemplateDir(t, map[string]string{"weekly.html": "before"})
+	path := filepath.Join(dir, "weekly.html")
+	server := startTestPreview(t, dir)
+
+	res, err := http.Get(server.URL + "/events")
+	if err != nil {
+		t.Fatalf("GET /events: %v", err)
+	}
+	defer res.Body.Close()
+	events := bufio.NewReader(res.Body)
+	if line, _ := events.ReadString('\n'); !strings.HasPrefix(line, ": connected") {
+		t.Fatalf("expected connected comment, got %q", line)
+	}
+
+	if err := os.WriteFile(path, []byte("after {{.CurrentWeek.TotalCommits}}"), 0644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	received := make(chan string, 1)
+	go func() {
+		for {
+			line, err := events.ReadString('\n')
+			if err != nil || strings.HasPrefix(line, "event:") {
+				received <- line
+				return
+			}
+		}
+	}()
+	select {
+	case line := <-received:
+		if strings.TrimSpace(line) != "event: reload" {
+			t.Fatalf("expected reload event, got %q", line)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for reload event")
+	}
+
+	if _, html := getPreview(t, server.URL+"/html"); html != "after 42" {
+		t.Errorf("expected updated template, got %q", html)
+	}
+}
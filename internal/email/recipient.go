@@ -0,0 +1,149 @@
+package email
+
+import (
+	"fmt"
+	"github-weekly-log/internal/i18n"
+	"net/mail"
+	"slices"
+	"strings"
+)
+
+// 週間レポートのセクション（送信先ごとに表示するものを選ぶ）
+// 期間・コミット数・活動日数のサマリーは常に表示する
+const (
+	SectionStreaks    = "streaks"    // 継続記録
+	SectionTrends     = "trends"     // トレンド
+	SectionGoals      = "goals"      // 今週の目標
+	SectionWorkLife   = "worklife"   // ワークライフバランス
+	SectionDaily      = "daily"      // 頑張りゲージ（日別のコミット数）
+	SectionCharts     = "charts"     // グラフ
+	SectionRepos      = "repos"      // リポジトリ別活動
+	SectionShipped    = "shipped"    // 今週の成果
+	SectionHighlights = "highlights" // ハイライト
+	SectionHotspots   = "hotspots"   // よく変更したファイル
+	SectionLanguages  = "languages"  // 主要言語
+	SectionHeatmap    = "heatmap"    // 曜日×時間帯の活動
+)
+
+// セクションの一覧（表示順）
+var Sections = []string{
+	SectionStreaks, SectionTrends, SectionGoals, SectionWorkLife, SectionDaily, SectionCharts,
+	SectionRepos, SectionShipped, SectionHighlights, SectionHotspots, SectionLanguages, SectionHeatmap,
+}
+
+// レポートの送信先
+type Recipient struct {
+	Name     string        // 宛名（空の場合は宛名なし）
+	Address  string        // 送信先アドレス
+	Language i18n.Language // レポートの言語（空の場合は i18n.Default）
+	Sections []string      // 表示するセクション（空の場合はすべて）
+	Cc       []string      // CC
+	Bcc      []string      // BCC
+}
+
+// セクションを表示するか
+func (r Recipient) Show(section string) bool {
+	return len(r.Sections) == 0 || slices.Contains(r.Sections, section)
+}
+
+// To ヘッダー用のアドレス（宛名がある場合は "名前 <address>"）
+func (r Recipient) To() string {
+	if r.Name == "" {
+		return r.Address
+	}
+	return (&mail.Address{Name: r.Name, Address: r.Address}).String()
+}
+
+// 送信先の設定を解析
+// 送信先は ";" 区切りで、各送信先は "|" 区切りでオプションを指定する
+//
+//	"Me <me@example.com>; boss@example.com|name=上司|sections=goals,repos|cc=lead@example.com; archive@example.com|bcc=backup@example.com"
+//
+// オプション: name（宛名）、lang（言語）、sections（表示するセクション、all ですべて）、cc / bcc（"," 区切り）
+func ParseRecipients(spec string) ([]Recipient, error) {
+	var recipients []Recipient
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		fields := strings.Split(entry, "|")
+
+		addr, err := mail.ParseAddress(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, fmt.Errorf("送信先のアドレスが不正です (%s): %w", fields[0], err)
+		}
+		recipient := Recipient{Name: addr.Name, Address: addr.Address}
+
+		for _, option := range fields[1:] {
+			key, value, ok := strings.Cut(option, "=")
+			if !ok {
+				return nil, fmt.Errorf("送信先のオプションが不正です: %q", option)
+			}
+			value = strings.TrimSpace(value)
+			switch strings.TrimSpace(key) {
+			case "name":
+				recipient.Name = value
+			case "lang":
+				if recipient.Language, err = i18n.Parse(value); err != nil {
+					return nil, err
+				}
+			case "sections":
+				if recipient.Sections, err = parseSections(value); err != nil {
+					return nil, err
+				}
+			case "cc":
+				if recipient.Cc, err = parseAddressList(value); err != nil {
+					return nil, err
+				}
+			case "bcc":
+				if recipient.Bcc, err = parseAddressList(value); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, fmt.Errorf("未対応の送信先オプションです: %q", key)
+			}
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, nil
+}
+
+// 表示するセクションを解析（all の場合は nil）
+func parseSections(value string) ([]string, error) {
+	if value == "all" {
+		return nil, nil
+	}
+	var sections []string
+	for _, section := range strings.Split(value, ",") {
+		section = strings.TrimSpace(section)
+		if section == "" {
+			continue
+		}
+		if !slices.Contains(Sections, section) {
+			return nil, fmt.Errorf("未対応のセクションです: %q（%s）", section, strings.Join(Sections, ", "))
+		}
+		sections = append(sections, section)
+	}
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("セクションが指定されていません")
+	}
+	return sections, nil
+}
+
+// "," 区切りのアドレスを解析
+func parseAddressList(value string) ([]string, error) {
+	var addresses []string
+	for _, address := range strings.Split(value, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		addr, err := mail.ParseAddress(address)
+		if err != nil {
+			return nil, fmt.Errorf("アドレスが不正です (%s): %w", address, err)
+		}
+		addresses = append(addresses, addr.Address)
+	}
+	return addresses, nil
+}
@@ -0,0 +1,174 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// テスト: 送信先の設定の解析
+func TestParseRecipients(t *testing.T) {
+	recipients, err := ParseRecipients("Me <me@example.com>; boss@example.com|name=上司|sections=goals, repos|cc=lead@example.com,Team <team@example.com>; archive@example.com|sections=all|bcc=backup@example.com;")
+	if err != nil {
+		t.Fatalf("ParseRecipients: %v", err)
+	}
+	if len(recipients) != 3 {
+		t.Fatalf("expected 3 recipients, got %d", len(recipients))
+	}
+
+	if me := recipients[0]; me.Name != "Me" || me.Address != "me@example.com" || me.To() != `"Me" <me@example.com>` {
+		t.Errorf("recipient[0]: got %+v (%s)", me, me.To())
+	}
+	boss := recipients[1]
+	if boss.Name != "上司" || strings.Join(boss.Sections, ",") != "goals,repos" || strings.Join(boss.Cc, ",") != "lead@example.com,team@example.com" {
+		t.Errorf("recipient[1]: got %+v", boss)
+	}
+	if !boss.Show(SectionGoals) || boss.Show(SectionCharts) {
+		t.Errorf("recipient[1]: expected only goals and repos to be shown")
+	}
+	archive := recipients[2]
+	if archive.Sections != nil || !archive.Show(SectionHeatmap) || strings.Join(archive.Bcc, ",") != "backup@example.com" || archive.To() != "archive@example.com" {
+		t.Errorf("recipient[2]: got %+v", archive)
+	}
+}
+
+// テスト: 不正な送信先の設定はエラー
+func TestParseRecipientsError(t *testing.T) {
+	for spec, want := range map[string]string{
+		"not-an-address":                    "送信先のアドレスが不正です",
+		"me@example.com|sections":           "送信先のオプションが不正です",
+		"me@example.com|sections=unknown":   "未対応のセクションです",
+		"me@example.com|sections=,":         "セクションが指定されていません",
+		"me@example.com|lang=fr":            "未対応の言語です",
+		"me@example.com|cc=broken":          "アドレスが不正です",
+		"me@example.com|reply-to=a@example": "未対応の送信先オプションです",
+	} {
+		if _, err := ParseRecipients(spec); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: expected %q, got %v", spec, want, err)
+		}
+	}
+}
+
+// テスト: 送信先ごとに表示するセクションと宛名を切り替える
+func TestRecipientSections(t *testing.T) {
+	comparison := SampleWeeklyComparison(time.Now())
+	charts, err := RenderCharts(comparison)
+	if err != nil {
+		t.Fatalf("RenderCharts: %v", err)
+	}
+	recipient := Recipient{Name: "上司", Address: "boss@example.com", Sections: []string{SectionGoals}}
+
+	all, err := LoadTemplateWithCharts(comparison, charts)
+	if err != nil {
+		t.Fatalf("LoadTemplateWithCharts: %v", err)
+	}
+	html, err := LoadRecipientTemplate(comparison, charts, recipient)
+	if err != nil {
+		t.Fatalf("LoadRecipientTemplate: %v", err)
+	}
+	for _, section := range []string{"今週の目標", "リポジトリ別活動", "頑張りゲージ", "曜日×時間帯の活動", "cid:"} {
+		if !strings.Contains(all, section) {
+			t.Errorf("expected %q in full report", section)
+		}
+	}
+	if !strings.Contains(html, "上司 さん、今週もお疲れ様でした") || !strings.Contains(html, "今週の目標") {
+		t.Errorf("expected greeting and goals")
+	}
+	for _, hidden := range []string{"リポジトリ別活動", "頑張りゲージ", "曜日×時間帯の活動", "cid:"} {
+		if strings.Contains(html, hidden) {
+			t.Errorf("expected %q to be hidden", hidden)
+		}
+	}
+
+	text := RenderRecipientText(comparison, recipient)
+	if !strings.Contains(text, "上司 さん、今週もお疲れ様でした") || !strings.Contains(text, "■ 今週の目標") {
+		t.Errorf("expected greeting and goals in text")
+	}
+	for _, hidden := range []string{"■ リポジトリ別活動", "■ 日別のコミット数", "■ ワークライフバランス"} {
+		if strings.Contains(text, hidden) {
+			t.Errorf("expected %q to be hidden in text", hidden)
+		}
+	}
+}
+
+// 送信したメールを記録するテスト用の送信方法
+type recordingSender struct {
+	messages []Message
+	fail     string // このアドレスへの送信は失敗させる
+}
+
+func (s *recordingSender) Send(ctx context.Context, msg Message) (string, error) {
+	if strings.Contains(msg.To[0], s.fail) {
+		return "", errors.New("rejected")
+	}
+	s.messages = append(s.messages, msg)
+	return "id-" + msg.To[0], nil
+}
+
+// テスト: 送信に失敗しても残りの送信先には送信する
+func TestSendWeeklyReports(t *testing.T) {
+	comparison := SampleWeeklyComparison(time.Now())
+	charts, err := RenderCharts(comparison)
+	if err != nil {
+		t.Fatalf("RenderCharts: %v", err)
+	}
+	sender := &recordingSender{fail: "broken@example.com"}
+	results := SendWeeklyReports(sender, comparison, charts, []Recipient{
+		{Address: "broken@example.com"},
+		{Name: "上司", Address: "boss@example.com", Sections: []string{SectionGoals}, Cc: []string{"lead@example.com"}, Bcc: []string{"backup@example.com"}},
+		{Address: "me@example.com"},
+	}, "report@example.com")
+
+	if len(results) != 3 || results[0].Err == nil || results[1].Err != nil || results[2].Err != nil {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if len(sender.messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(sender.messages))
+	}
+	boss, me := sender.messages[0], sender.messages[1]
+	if to, err := mail.ParseAddress(boss.To[0]); err != nil || to.Name != "上司" || to.Address != "boss@example.com" {
+		t.Errorf("boss To: got %v (%v)", boss.To, err)
+	}
+	if boss.Cc[0] != "lead@example.com" || boss.Bcc[0] != "backup@example.com" {
+		t.Errorf("boss: got Cc=%v Bcc=%v", boss.Cc, boss.Bcc)
+	}
+	if len(boss.Inline) != 0 || len(me.Inline) == 0 {
+		t.Errorf("expected charts only for recipients showing them, got %d / %d", len(boss.Inline), len(me.Inline))
+	}
+	if results[2].ID != "id-me@example.com" {
+		t.Errorf("ID: got %q", results[2].ID)
+	}
+	if key := DeliveryKey(comparison, Recipient{Address: "Me@Example.com"}); me.IdempotencyKey != key || results[2].Key != key {
+		t.Errorf("IdempotencyKey: got %q / %q, expected %q", me.IdempotencyKey, results[2].Key, key)
+	}
+	if boss.IdempotencyKey == me.IdempotencyKey {
+		t.Errorf("expected a different key per recipient")
+	}
+}
+
+// テスト: チームダイジェストはチームと週と送信先ごとの冪等キーで送信する
+func TestSendTeamReportIdempotencyKey(t *testing.T) {
+	week := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+	report := github.TeamReport{
+		Members: []github.MemberReport{{Username: "alice"}, {Username: "bob"}},
+		Team:    &github.TeamStats{StartDate: week},
+	}
+	key := TeamDeliveryKey(report, "Lead@Example.com")
+	if key != "team/alice+bob/2026-02-14/lead@example.com" {
+		t.Fatalf("TeamDeliveryKey: got %q", key)
+	}
+
+	sender := &recordingSender{fail: "broken@example.com"}
+	id, err := SendTeamReport(sender, "<p>team</p>", "team", i18n.Japanese, "report@example.com", "lead@example.com", key)
+	if err != nil || id != "id-lead@example.com" {
+		t.Fatalf("SendTeamReport: got %q (%v)", id, err)
+	}
+	if len(sender.messages) != 1 || sender.messages[0].IdempotencyKey != key {
+		t.Errorf("expected the team key, got %+v", sender.messages)
+	}
+}
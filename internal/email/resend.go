@@ -0,0 +1,73 @@
+package email
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/resend/resend-go/v3"
+)
+
+// Resend の API のタイムアウト（SDK の既定値と同じ）
+const resendTimeout = time.Minute
+
+// Resend の API で送信する
+type ResendSender struct {
+	client *resend.Client
+}
+
+// Resend の送信方法を生成
+func NewResendSender(apiKey string) *ResendSender {
+	httpClient := &http.Client{Timeout: resendTimeout, Transport: statusTransport{base: http.DefaultTransport}}
+	return &ResendSender{client: resend.NewCustomClient(httpClient, apiKey)}
+}
+
+// レスポンスのステータスコードを記録する ctx のキー
+type statusKey struct{}
+
+// SDK のエラーにはステータスコードが含まれないため、ctx に渡した変数に記録する
+type statusTransport struct {
+	base http.RoundTripper
+}
+
+func (t statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if status, ok := req.Context().Value(statusKey{}).(*int); ok && resp != nil {
+		*status = resp.StatusCode
+	}
+	return resp, err
+}
+
+// メールを送信
+func (s *ResendSender) Send(ctx context.Context, msg Message) (string, error) {
+	params := &resend.SendEmailRequest{
+		From:    msg.From,
+		To:      msg.To,
+		Cc:      msg.Cc,
+		Bcc:     msg.Bcc,
+		Html:    msg.HTML,
+		Text:    msg.Text,
+		Subject: msg.Subject,
+	}
+	for _, image := range msg.Inline {
+		params.Attachments = append(params.Attachments, &resend.Attachment{
+			Content:     image.Data,
+			Filename:    image.Filename,
+			ContentType: image.ContentType,
+			ContentId:   image.ContentID,
+		})
+	}
+
+	// 冪等キーが空の場合はヘッダーを付けない
+	options := &resend.SendEmailOptions{IdempotencyKey: msg.IdempotencyKey}
+	var status int
+	sent, err := s.client.Emails.SendWithOptions(context.WithValue(ctx, statusKey{}, &status), params, options)
+	if err != nil {
+		// レート制限（429）以外の 4xx は認証やリクエストの誤りなので再試行しない
+		if status >= 400 && status < 500 && status != http.StatusTooManyRequests {
+			return "", permanent(err)
+		}
+		return "", err
+	}
+	return sent.Id, nil
+}
@@ -0,0 +1,255 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github-weekly-log/internal/i18n"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Resend API の POST /emails が受け取ったリクエスト
+type fakeResendRequest struct {
+	Auth           string   // Authorization ヘッダー
+	IdempotencyKey string   // Idempotency-Key ヘッダー
+	From           string   `json:"from"`
+	To             []string `json:"to"`
+	Cc             []string `json:"cc"`
+	Bcc            []string `json:"bcc"`
+	Subject        string   `json:"subject"`
+	HTML           string   `json:"html"`
+	Text           string   `json:"text"`
+	Attachments    []struct {
+		Content     []byte `json:"-"`
+		RawContent  []int  `json:"content"`
+		Filename    string `json:"filename"`
+		ContentType string `json:"content_type"`
+		ContentID   string `json:"content_id"`
+	} `json:"attachments"`
+}
+
+// テスト用の Resend API（httptest）
+type fakeResend struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	requests []fakeResendRequest
+	failures []int // 先頭から順に返すエラーのステータスコード（なくなると成功する）
+}
+
+// テスト用の Resend API を起動（テスト終了時に停止する）
+func newFakeResend(t *testing.T, failures ...int) *fakeResend {
+	t.Helper()
+	fake := &fakeResend{failures: failures}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.handle))
+	t.Cleanup(fake.server.Close)
+	return fake
+}
+
+func (f *fakeResend) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/emails" {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req fakeResendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.Auth = r.Header.Get("Authorization")
+	req.IdempotencyKey = r.Header.Get("Idempotency-Key")
+	for i, attachment := range req.Attachments {
+		for _, b := range attachment.RawContent {
+			req.Attachments[i].Content = append(req.Attachments[i].Content, byte(b))
+		}
+	}
+
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	id := fmt.Sprintf("email-%d", len(f.requests))
+	status := http.StatusOK
+	if len(f.failures) > 0 {
+		status, f.failures = f.failures[0], f.failures[1:]
+	}
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch status {
+	case http.StatusOK:
+		json.NewEncoder(w).Encode(map[string]string{"id": id})
+	case http.StatusTooManyRequests:
+		w.Header().Set("retry-after", "1")
+		w.WriteHeader(status)
+		fmt.Fprint(w, `{"message":"Too many requests"}`)
+	default:
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"message":"%s"}`, http.StatusText(status))
+	}
+}
+
+// 受け取ったリクエスト
+func (f *fakeResend) received() []fakeResendRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeResendRequest(nil), f.requests...)
+}
+
+// テスト用の API に送信する Resend の送信方法
+func (f *fakeResend) sender(t *testing.T) *ResendSender {
+	t.Helper()
+	sender := NewResendSender("re_test")
+	baseURL, err := url.Parse(f.server.URL + "/")
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+	sender.client.BaseURL = baseURL
+	return sender
+}
+
+// テスト: 送信元・送信先・件名・本文・インライン画像・冪等キーを API に渡す
+func TestResendSender(t *testing.T) {
+	fake := newFakeResend(t)
+	image := InlineImage{ContentID: "daily-chart", Filename: "daily-chart.png", ContentType: "image/png", Data: []byte("\x89PNG\x00\xff")}
+
+	id, err := fake.sender(t).Send(context.Background(), Message{
+		From:           "お疲れ様委員会 <report@example.com>",
+		To:             []string{"me@example.com"},
+		Cc:             []string{"lead@example.com"},
+		Bcc:            []string{"hidden@example.com"},
+		Subject:        "週間コミットレポート (2026/02/21)",
+		HTML:           `<img src="cid:daily-chart">`,
+		Text:           "総コミット数: 42",
+		Inline:         []InlineImage{image},
+		IdempotencyKey: "weekly/alice/2026-02-14/me@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if id != "email-1" {
+		t.Errorf("ID: got %q", id)
+	}
+
+	requests := fake.received()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	req := requests[0]
+	if req.Auth != "Bearer re_test" || req.IdempotencyKey != "weekly/alice/2026-02-14/me@example.com" {
+		t.Errorf("headers: got Authorization=%q Idempotency-Key=%q", req.Auth, req.IdempotencyKey)
+	}
+	if req.From != "お疲れ様委員会 <report@example.com>" || req.Subject != "週間コミットレポート (2026/02/21)" {
+		t.Errorf("From/Subject: got %q / %q", req.From, req.Subject)
+	}
+	if strings.Join(req.To, ",") != "me@example.com" || strings.Join(req.Cc, ",") != "lead@example.com" || strings.Join(req.Bcc, ",") != "hidden@example.com" {
+		t.Errorf("recipients: got To=%v Cc=%v Bcc=%v", req.To, req.Cc, req.Bcc)
+	}
+	if req.HTML != `<img src="cid:daily-chart">` || req.Text != "総コミット数: 42" {
+		t.Errorf("bodies: got %q / %q", req.HTML, req.Text)
+	}
+	if len(req.Attachments) != 1 || req.Attachments[0].ContentID != "daily-chart" || string(req.Attachments[0].Content) != string(image.Data) {
+		t.Errorf("attachments: got %+v", req.Attachments)
+	}
+}
+
+// テスト: 冪等キーがない場合は Idempotency-Key ヘッダーを付けない
+func TestResendSenderWithoutIdempotencyKey(t *testing.T) {
+	fake := newFakeResend(t)
+	if _, err := fake.sender(t).Send(context.Background(), Message{From: "report@example.com", To: []string{"me@example.com"}, Subject: "report", HTML: "<p>report</p>"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if key := fake.received()[0].IdempotencyKey; key != "" {
+		t.Errorf("expected no Idempotency-Key, got %q", key)
+	}
+}
+
+// テスト: API のエラーとレート制限は同じ冪等キーで再試行する
+func TestResendSenderRetry(t *testing.T) {
+	fake := newFakeResend(t, http.StatusInternalServerError, http.StatusTooManyRequests)
+	retry, waits := newTestRetrySender(fake.sender(t), RetryConfig{Attempts: 3, BaseDelay: time.Millisecond})
+
+	id, err := retry.Send(context.Background(), Message{From: "report@example.com", To: []string{"me@example.com"}, Subject: "report", HTML: "<p>report</p>", IdempotencyKey: "weekly/alice/2026-02-14/me@example.com"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	requests := fake.received()
+	if id != "email-3" || len(requests) != 3 {
+		t.Fatalf("expected success on the 3rd request, got %q after %d requests", id, len(requests))
+	}
+	for _, req := range requests {
+		if req.IdempotencyKey != "weekly/alice/2026-02-14/me@example.com" {
+			t.Errorf("expected the same Idempotency-Key, got %q", req.IdempotencyKey)
+		}
+	}
+	// レート制限では retry-after（1秒）だけ待つ
+	if len(*waits) != 2 || (*waits)[0] != time.Millisecond || (*waits)[1] != time.Second {
+		t.Errorf("waits: got %v", *waits)
+	}
+}
+
+// テスト: レート制限以外の 4xx（認証エラー・不正なリクエスト）は再試行しない
+func TestResendSenderPermanentError(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusUnprocessableEntity} {
+		fake := newFakeResend(t, status)
+		retry, waits := newTestRetrySender(fake.sender(t), RetryConfig{Attempts: 3, BaseDelay: time.Millisecond})
+
+		if _, err := retry.Send(context.Background(), Message{From: "report@example.com", To: []string{"me@example.com"}, Subject: "report", HTML: "<p>report</p>"}); err == nil {
+			t.Fatalf("%d: expected error", status)
+		}
+		if requests := fake.received(); len(requests) != 1 || len(*waits) != 0 {
+			t.Errorf("%d: expected no retry, got %d requests and waits %v", status, len(requests), *waits)
+		}
+	}
+}
+
+// テスト: 週間レポートを送信先ごとの言語・宛名・セクションで Resend に送信する
+func TestSendWeeklyReportsResend(t *testing.T) {
+	fake := newFakeResend(t)
+	comparison := SampleWeeklyComparison(time.Now())
+	charts, err := RenderCharts(comparison)
+	if err != nil {
+		t.Fatalf("RenderCharts: %v", err)
+	}
+
+	results := SendWeeklyReports(fake.sender(t), comparison, charts, []Recipient{
+		{Address: "me@example.com"},
+		{Name: "Boss", Address: "boss@example.com", Language: i18n.English, Sections: []string{SectionGoals}, Cc: []string{"lead@example.com"}},
+	}, "report@example.com")
+	for _, result := range results {
+		if result.Err != nil {
+			t.Fatalf("%s: %v", result.Recipient.Address, result.Err)
+		}
+	}
+
+	requests := fake.received()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	me, boss := requests[0], requests[1]
+	today := time.Now()
+	if me.Subject != "週間コミットレポート ("+today.Format("2006/01/02")+")" || me.From != "お疲れ様委員会 <report@example.com>" {
+		t.Errorf("me: got Subject=%q From=%q", me.Subject, me.From)
+	}
+	if boss.Subject != "Weekly commit report ("+today.Format("Jan 2, 2006")+")" || boss.From != "Weekly Log <report@example.com>" {
+		t.Errorf("boss: got Subject=%q From=%q", boss.Subject, boss.From)
+	}
+	if to, err := mail.ParseAddress(boss.To[0]); err != nil || to.Name != "Boss" || strings.Join(boss.Cc, ",") != "lead@example.com" {
+		t.Errorf("boss recipients: got To=%v Cc=%v (%v)", boss.To, boss.Cc, err)
+	}
+	if !strings.Contains(boss.Text, "Great work this week, Boss!") || strings.Contains(boss.Text, "Commits per repository") {
+		t.Errorf("boss: expected greeting and only selected sections, got %q", boss.Text)
+	}
+	if len(me.Attachments) == 0 || len(boss.Attachments) != 0 {
+		t.Errorf("expected charts only for recipients showing them, got %d / %d", len(me.Attachments), len(boss.Attachments))
+	}
+	if me.IdempotencyKey != results[0].Key || boss.IdempotencyKey != results[1].Key || me.IdempotencyKey == boss.IdempotencyKey {
+		t.Errorf("idempotency keys: got %q / %q", me.IdempotencyKey, boss.IdempotencyKey)
+	}
+}
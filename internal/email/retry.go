@@ -0,0 +1,124 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"github-weekly-log/internal/i18n"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/resend/resend-go/v3"
+)
+
+// 再試行の既定値
+const (
+	defaultRetryAttempts  = 3
+	defaultRetryBaseDelay = 2 * time.Second
+	defaultRetryMaxDelay  = 30 * time.Second
+)
+
+// 送信に失敗した場合の再試行の設定
+type RetryConfig struct {
+	Attempts  int           // 最大試行回数（0 の場合は 3 回、1 の場合は再試行しない）
+	BaseDelay time.Duration // 1回目の再試行までの待ち時間（0 の場合は 2秒、以降は2倍ずつ延ばす）
+	MaxDelay  time.Duration // 待ち時間の上限（0 の場合は 30秒）
+}
+
+// 失敗した送信を指数バックオフで再試行する
+// 再送で二重に届かないよう、送信先の API・サーバーには Message.IdempotencyKey を渡す
+type RetrySender struct {
+	sender Sender
+	cfg    RetryConfig
+	sleep  func(ctx context.Context, d time.Duration) error // テストで差し替える
+}
+
+// 再試行付きの送信方法を生成
+func NewRetrySender(sender Sender, cfg RetryConfig) *RetrySender {
+	if cfg.Attempts <= 0 {
+		cfg.Attempts = defaultRetryAttempts
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = defaultRetryBaseDelay
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = defaultRetryMaxDelay
+	}
+	return &RetrySender{sender: sender, cfg: cfg, sleep: sleepContext}
+}
+
+// メールを送信し、一時的なエラーの場合は待ってから再試行する
+func (s *RetrySender) Send(ctx context.Context, msg Message) (string, error) {
+	delay := s.cfg.BaseDelay
+	for attempt := 1; ; attempt++ {
+		id, err := s.sender.Send(ctx, msg)
+		if err == nil || attempt >= s.cfg.Attempts || !isRetryable(err) {
+			return id, err
+		}
+
+		wait := max(delay, retryAfter(err))
+		wait = min(wait, s.cfg.MaxDelay)
+		i18n.Println("console.send_retry", attempt, s.cfg.Attempts, wait, err)
+		if err := s.sleep(ctx, wait); err != nil {
+			return "", err
+		}
+		delay *= 2
+	}
+}
+
+// 再試行しても成功しないエラー（アドレスの形式が不正な場合など）
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// 再試行しないエラーとしてラップする
+func permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// 再試行すれば成功する可能性があるエラーか
+// 中断された場合と、メッセージが不正な場合、SMTP サーバーが恒久的なエラー（5xx）、
+// Resend がレート制限以外の 4xx を返した場合（permanentError）は再試行しない
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var permanentErr *permanentError
+	if errors.As(err, &permanentErr) {
+		return false
+	}
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) {
+		return smtpErr.Code < 500
+	}
+	var missingErr *resend.MissingRequiredFieldsError
+	return !errors.As(err, &missingErr)
+}
+
+// レート制限の場合に API が指定した待ち時間
+func retryAfter(err error) time.Duration {
+	var rateLimitErr *resend.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		return 0
+	}
+	seconds, err := strconv.Atoi(rateLimitErr.RetryAfter)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// ctx が終了するまでの範囲で d だけ待つ
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,116 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/resend/resend-go/v3"
+)
+
+// 指定した回数だけ失敗する送信方法
+type flakySender struct {
+	errs  []error // 先頭から順に返すエラー（なくなると成功する）
+	calls int
+	keys  []string
+}
+
+func (s *flakySender) Send(ctx context.Context, msg Message) (string, error) {
+	s.calls++
+	s.keys = append(s.keys, msg.IdempotencyKey)
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return "", err
+	}
+	return "sent", nil
+}
+
+// 待ち時間を記録して待たずに戻る再試行の送信方法
+func newTestRetrySender(sender Sender, cfg RetryConfig) (*RetrySender, *[]time.Duration) {
+	retry := NewRetrySender(sender, cfg)
+	var waits []time.Duration
+	retry.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	return retry, &waits
+}
+
+// テスト: 一時的なエラーは指数バックオフで再試行し、同じ冪等キーで再送する
+func TestRetrySender(t *testing.T) {
+	sender := &flakySender{errs: []error{errors.New("503"), errors.New("timeout")}}
+	retry, waits := newTestRetrySender(sender, RetryConfig{Attempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute})
+
+	id, err := retry.Send(context.Background(), Message{IdempotencyKey: "weekly/alice/2026-02-14/me@example.com"})
+	if err != nil || id != "sent" {
+		t.Fatalf("Send: got %q, %v", id, err)
+	}
+	if sender.calls != 3 {
+		t.Errorf("calls: expected 3, got %d", sender.calls)
+	}
+	if len(*waits) != 2 || (*waits)[0] != time.Second || (*waits)[1] != 2*time.Second {
+		t.Errorf("waits: got %v", *waits)
+	}
+	for _, key := range sender.keys {
+		if key != "weekly/alice/2026-02-14/me@example.com" {
+			t.Errorf("expected the same idempotency key, got %q", key)
+		}
+	}
+}
+
+// テスト: 試行回数を使い切った場合は最後のエラーを返す
+func TestRetrySenderExhausted(t *testing.T) {
+	sender := &flakySender{errs: []error{errors.New("1"), errors.New("2"), errors.New("3")}}
+	retry, waits := newTestRetrySender(sender, RetryConfig{Attempts: 2, BaseDelay: time.Second})
+
+	if _, err := retry.Send(context.Background(), Message{}); err == nil || err.Error() != "2" {
+		t.Fatalf("expected last error, got %v", err)
+	}
+	if sender.calls != 2 || len(*waits) != 1 {
+		t.Errorf("expected 2 calls and 1 wait, got %d / %v", sender.calls, *waits)
+	}
+}
+
+// テスト: 恒久的なエラーは再試行しない
+func TestRetrySenderPermanent(t *testing.T) {
+	tests := map[string]error{
+		"smtp 5xx": &textproto.Error{Code: 550, Msg: "mailbox unavailable"},
+		"canceled": context.Canceled,
+	}
+	for name, sendErr := range tests {
+		sender := &flakySender{errs: []error{sendErr}}
+		retry, _ := newTestRetrySender(sender, RetryConfig{})
+		if _, err := retry.Send(context.Background(), Message{}); !errors.Is(err, sendErr) {
+			t.Errorf("%s: expected %v, got %v", name, sendErr, err)
+		}
+		if sender.calls != 1 {
+			t.Errorf("%s: expected no retry, got %d calls", name, sender.calls)
+		}
+	}
+
+	// SMTP の一時的なエラー（4xx）は再試行する
+	sender := &flakySender{errs: []error{&textproto.Error{Code: 421, Msg: "try again later"}}}
+	retry, _ := newTestRetrySender(sender, RetryConfig{})
+	if _, err := retry.Send(context.Background(), Message{}); err != nil || sender.calls != 2 {
+		t.Errorf("expected retry for 4xx, got %v after %d calls", err, sender.calls)
+	}
+}
+
+// テスト: レート制限の場合は API が指定した時間（上限まで）待つ
+func TestRetrySenderRateLimit(t *testing.T) {
+	sender := &flakySender{errs: []error{
+		&resend.RateLimitError{RetryAfter: "5"},
+		&resend.RateLimitError{RetryAfter: "120"},
+	}}
+	retry, waits := newTestRetrySender(sender, RetryConfig{Attempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second})
+
+	if _, err := retry.Send(context.Background(), Message{}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if len(*waits) != 2 || (*waits)[0] != 5*time.Second || (*waits)[1] != 10*time.Second {
+		t.Errorf("waits: got %v", *waits)
+	}
+}
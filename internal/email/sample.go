@@ -0,0 +1,135 @@
+package email
+
+import (
+	"github-weekly-log/internal/github"
+	"time"
+)
+
+// 曜日の表示名
+var sampleWeekdays = []string{"日", "月", "火", "水", "木", "金", "土"}
+
+// 日別のコミット数から DailyCommits を生成
+func sampleDailyCommits(start time.Time, counts []int) []github.DailyCommit {
+	days := make([]github.DailyCommit, 0, len(counts))
+	for i, count := range counts {
+		date := start.AddDate(0, 0, i)
+		days = append(days, github.DailyCommit{Date: date, DateStr: date.Format("1/2"), Weekday: sampleWeekdays[date.Weekday()], Count: count})
+	}
+	return days
+}
+
+// テスト送信・テンプレート検証用の週間データ（now までの1週間）
+// テンプレートの各セクションが表示されるように、任意のデータもすべて設定する
+func SampleWeeklyComparison(now time.Time) github.WeeklyComparison {
+	startDate := now.AddDate(0, 0, -7)
+	prevStartDate := now.AddDate(0, 0, -14)
+
+	var heatmap github.Heatmap
+	heatmap[1][10], heatmap[2][14], heatmap[4][23], heatmap[6][11] = 6, 9, 2, 3
+
+	stats := github.WeeklyStats{
+		Username:           "mizunoryuki",
+		TotalCommits:       42,
+		ActiveDays:         5,
+		MergedPullRequests: 2,
+		StartDate:          startDate,
+		EndDate:            now,
+		DailyCommits:       sampleDailyCommits(startDate, []int{5, 12, 0, 8, 15, 2, 0}),
+		RepoDetails: []github.RepoDetail{
+			{Name: "awesome-project", Count: 25, BarPercent: 100.0, PrimaryLanguage: "Go", Additions: 820, Deletions: 140},
+			{Name: "go-utils", Count: 12, BarPercent: 48.0, PrimaryLanguage: "Go", Additions: 210, Deletions: 35},
+			{Name: "dotfiles", Count: 5, BarPercent: 20.0, Private: true, Additions: 12, Deletions: 4},
+		},
+		MainLanguages: map[string]int{
+			"Go":         120,
+			"TypeScript": 85,
+			"Python":     30,
+		},
+		LanguageCommits:       map[string]int{"Go": 30, "TypeScript": 10, "Python": 4},
+		HourlyActivity:        [24]int{9: 4, 10: 6, 11: 3, 14: 9, 15: 7, 16: 5, 21: 3, 23: 2},
+		WeekdayHourlyActivity: heatmap,
+		CommitTypes:           map[string]int{"feat": 12, "fix": 8, "chore": 6, "other": 16},
+		Shipped: []github.ShippedRepo{
+			{Repo: "awesome-project", Items: []github.ShippedItem{{Type: "feat", Scope: "auth", Subject: "ログイン機能を追加"}, {Type: "fix", Subject: "起動時のクラッシュを修正"}}},
+		},
+		Highlights: []github.RepoHighlights{
+			{Repo: "awesome-project", Items: []github.Highlight{{Kind: github.HighlightRelease, Title: "v1.2.0", URL: "https://github.com/mizunoryuki/awesome-project/releases/tag/v1.2.0"}, {Kind: github.HighlightCommit, Title: "依存関係を更新"}}},
+		},
+		Hotspots: []github.RepoHotspots{
+			{Repo: "awesome-project", Files: []github.Hotspot{{Path: "cmd/main.go", Changes: 6, Churn: 180}}, Directories: []github.Hotspot{{Path: "internal", Changes: 14}}},
+		},
+		WorkLife: github.WorkLifeBalance{AfterHoursCommits: 10, AfterHoursShare: 24, WeekendCommits: 2, WeekendShare: 5, LateNightCommits: 2},
+		Goals: []github.GoalResult{
+			{Metric: github.GoalActiveDays, Label: "活動日数", Target: 5, Actual: 5, Percent: 100, Achieved: true},
+			{Metric: github.GoalCommits, Label: "コミット数", Target: 50, Actual: 42, Percent: 84},
+		},
+	}
+
+	return github.WeeklyComparison{
+		CurrentWeek: &stats,
+		PreviousWeek: &github.WeeklyStats{
+			Username:     "mizunoryuki",
+			TotalCommits: 30,
+			ActiveDays:   4,
+			StartDate:    prevStartDate,
+			EndDate:      startDate,
+			DailyCommits: sampleDailyCommits(prevStartDate, []int{3, 8, 0, 12, 7, 0, 0}),
+			RepoDetails: []github.RepoDetail{
+				{Name: "awesome-project", Count: 18, BarPercent: 100.0},
+				{Name: "go-utils", Count: 8, BarPercent: 44.4},
+				{Name: "dotfiles", Count: 4, BarPercent: 22.2},
+			},
+			MainLanguages: map[string]int{
+				"Go":         90,
+				"TypeScript": 60,
+				"Python":     20,
+			},
+		},
+		CommitsDiff:       12,
+		CommitsChangeRate: 40,
+		LanguageMetric:    github.LanguageMetricFiles,
+		Streaks:           &github.Streaks{CurrentDays: 3, LongestDays: 9, CurrentWeeks: 6, LongestWeeks: 10},
+		Trends: &github.Trends{
+			Weeks:      4,
+			Commits:    github.MetricTrend{Current: 42, Average4Weeks: 31.5, Average12Weeks: 28, ChangeRate4Weeks: 33, ChangeRate12Weeks: 50, LastYear: 20, HasLastYear: true, Direction: "up"},
+			ActiveDays: github.MetricTrend{Current: 5, Average4Weeks: 4.5, Average12Weeks: 4, ChangeRate4Weeks: 11, ChangeRate12Weeks: 25, Direction: "flat"},
+			Repos:      []github.ItemTrend{{Name: "awesome-project", Current: 25, Average4Weeks: 15, Direction: "up"}},
+			Languages:  []github.ItemTrend{{Name: "Go", Current: 120, Average4Weeks: 130, Direction: "down"}},
+		},
+		LongTermHeatmap: &heatmap,
+		LongTermWeeks:   4,
+		WorkLifeWarning: &github.WorkLifeWarning{ConsecutiveWeeks: 3, AfterHours: true},
+		GoalSummaries: []github.GoalSummary{
+			{Metric: github.GoalActiveDays, Label: "活動日数", Weeks: 4, Achieved: 3, HitRate: 75},
+			{Metric: github.GoalCommits, Label: "コミット数", Weeks: 4, Achieved: 1, HitRate: 25},
+		},
+	}
+}
+
+// テンプレート検証用のチームレポート（now までの1週間）
+func SampleTeamReport(now time.Time) github.TeamReport {
+	comparison := SampleWeeklyComparison(now)
+	current := comparison.CurrentWeek
+	return github.TeamReport{
+		Members: []github.MemberReport{{Username: current.Username, Comparison: &comparison}},
+		Team: &github.TeamStats{
+			TotalCommits:      54,
+			PreviousCommits:   40,
+			CommitsDiff:       14,
+			CommitsChangeRate: 35,
+			ActiveMembers:     2,
+			Members: []github.MemberSummary{
+				{Username: current.Username, TotalCommits: 42, ActiveDays: 5, CommitsDiff: 12},
+				{Username: "alice", TotalCommits: 12, ActiveDays: 3, CommitsDiff: -2},
+			},
+			RepoDetails: []github.RepoDetail{
+				{Name: "mizunoryuki/awesome-project", Count: 25, BarPercent: 100.0},
+				{Name: "alice/api", Count: 12, BarPercent: 48.0, Private: true},
+			},
+			LanguageCommits: map[string]int{"Go": 34, "TypeScript": 14},
+			MainLanguages:   map[string]int{"Go": 34, "TypeScript": 14},
+			StartDate:       current.StartDate,
+			EndDate:         current.EndDate,
+		},
+	}
+}
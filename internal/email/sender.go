@@ -1,166 +1,245 @@
 package email
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
 	"os"
-	"text/template"
+	"strings"
 	"time"
-
-	"github.com/resend/resend-go/v3"
 )
 
+// 週間レポートのテンプレートに渡すデータ（グラフは nil の場合は表示しない）
+type weeklyTemplateData struct {
+	github.WeeklyComparison
+	Charts    *Charts
+	Recipient Recipient
+	Lang      i18n.Language // 表示する言語（テンプレートから {{.Lang.T "report.repos"}} のように使う）
+}
+
+// セクションを表示するか（テンプレートから {{if .Show "goals"}} のように使う）
+func (d weeklyTemplateData) Show(section string) bool {
+	return d.Recipient.Show(section)
+}
+
 // templateを読み込み、ファイルにデータを埋め込む
 func LoadTemplate(comparison github.WeeklyComparison) (string, error) {
-	tmpl, err := template.ParseFiles("templates/dist/weekly.html")
-	if err != nil {
-		return "", fmt.Errorf("テンプレート読み込み失敗: %w", err)
-	}
+	return LoadTemplateWithCharts(comparison, nil)
+}
+
+// グラフを含めて週間レポートのtemplateにデータを埋め込む
+func LoadTemplateWithCharts(comparison github.WeeklyComparison, charts *Charts) (string, error) {
+	return LoadRecipientTemplate(comparison, charts, Recipient{})
+}
+
+// 送信先に合わせて（宛名・表示するセクション）週間レポートのtemplateにデータを埋め込む
+func LoadRecipientTemplate(comparison github.WeeklyComparison, charts *Charts, recipient Recipient) (string, error) {
+	return renderTemplate(TemplateWeekly, weeklyTemplateData{WeeklyComparison: comparison, Charts: charts, Recipient: recipient, Lang: recipient.Language.OrDefault()})
+}
+
+// チームダイジェストのテンプレートに渡すデータ
+type teamTemplateData struct {
+	github.TeamReport
+	Lang i18n.Language // 表示する言語
+}
 
-	// データをテンプレートに埋め込む
-	var buf bytes.Buffer
-	// Executeでデータを埋め込む
-	if err := tmpl.Execute(&buf, comparison); err != nil {
-		return "", fmt.Errorf("テンプレート実行失敗: %w", err)
+// チームレポート用のtemplateを読み込み、データを埋め込む
+func LoadTeamTemplate(report github.TeamReport, lang i18n.Language) (string, error) {
+	return renderTemplate(TemplateTeam, teamTemplateData{TeamReport: report, Lang: lang.OrDefault()})
+}
+
+// 送信するメール
+type Message struct {
+	From    string        // 送信元（"名前 <address>" 形式も可）
+	To      []string      // 送信先
+	Cc      []string      // CC
+	Bcc     []string      // BCC（ヘッダーには含めない）
+	Subject string        // 件名
+	HTML    string        // HTML本文
+	Text    string        // テキスト本文（text/plain の代替パート、空の場合は HTML のみ）
+	Inline  []InlineImage // HTML から cid: で参照する画像
+
+	IdempotencyKey string // 再送時に二重送信を防ぐためのキー（空の場合は指定しない）
+}
+
+// メールの送信方法
+type Sender interface {
+	// メールを送信し、送信サービス上のIDを返す
+	Send(ctx context.Context, msg Message) (string, error)
+}
+
+// 送信方法の種類
+const (
+	ProviderResend = "resend"
+	ProviderSMTP   = "smtp"
+	ProviderFile   = "file" // 送信せずにディレクトリへ書き出す
+)
+
+// 送信方法の設定
+type Config struct {
+	Provider     string      // ProviderResend（既定）/ ProviderSMTP / ProviderFile
+	ResendAPIKey string      // Resend の APIキー
+	SMTP         SMTPConfig  // SMTP サーバーの設定
+	File         FileConfig  // ファイルへの書き出しの設定
+	Retry        RetryConfig // 送信に失敗した場合の再試行
+}
+
+// 設定に応じた送信方法を生成（失敗した場合は cfg.Retry に従って再試行する）
+func NewSender(cfg Config) (Sender, error) {
+	var sender Sender
+	switch strings.ToLower(cfg.Provider) {
+	case "", ProviderResend:
+		sender = NewResendSender(cfg.ResendAPIKey)
+	case ProviderSMTP:
+		smtpSender, err := NewSMTPSender(cfg.SMTP)
+		if err != nil {
+			return nil, err
+		}
+		sender = smtpSender
+	case ProviderFile:
+		fileSender, err := NewFileSender(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		sender = fileSender
+	default:
+		return nil, fmt.Errorf("未対応の送信方法です: %q", cfg.Provider)
 	}
+	return NewRetrySender(sender, cfg.Retry), nil
+}
 
-	return buf.String(), nil
+// 送信先ごとの送信結果
+type SendResult struct {
+	Recipient Recipient
+	Key       string // 冪等キー（DeliveryKey）
+	ID        string // 送信サービス上のID
+	Err       error  // 送信に失敗した場合のエラー
 }
 
-// メール送信
-func SendWeeklyReport(apiKey string, htmlContent string, imagePath string, emailDomain string, emailTo string) error {
-	client := resend.NewClient(apiKey)
-	subject := fmt.Sprintf("週間コミットレポート (%s)", time.Now().Format("2006/01/02"))
+// 週と送信先ごとの冪等キー（"weekly/alice/2026-02-14/boss@example.com"）
+// 同じ週を同じ送信先に再送する場合は同じキーになる
+func DeliveryKey(comparison github.WeeklyComparison, recipient Recipient) string {
+	current := comparison.CurrentWeek
+	return fmt.Sprintf("weekly/%s/%s/%s", current.Username, current.StartDate.Format("2006-01-02"), strings.ToLower(recipient.Address))
+}
 
-	params := &resend.SendEmailRequest{
-		From:    "お疲れ様委員会 <" + emailDomain + ">",
-		To:      []string{emailTo},
-		Html:    htmlContent,
-		Subject: subject,
+// 週間レポートを送信先ごとに描画して送信する
+// 送信に失敗しても残りの送信先への送信は続ける
+func SendWeeklyReports(sender Sender, comparison github.WeeklyComparison, charts *Charts, recipients []Recipient, emailDomain string) []SendResult {
+	now := time.Now()
+	results := make([]SendResult, 0, len(recipients))
+	for _, recipient := range recipients {
+		lang := recipient.Language.OrDefault()
+		subject := lang.T("email.subject.weekly", lang.FullDate(now))
+		key := DeliveryKey(comparison, recipient)
+		id, err := sendWeeklyReportTo(sender, comparison, charts, recipient, subject, key, emailDomain)
+		results = append(results, SendResult{Recipient: recipient, Key: key, ID: id, Err: err})
 	}
+	return results
+}
 
-	sent, err := client.Emails.Send(params)
+// 1件の送信先に週間レポートを送信
+func sendWeeklyReportTo(sender Sender, comparison github.WeeklyComparison, charts *Charts, recipient Recipient, subject string, key string, emailDomain string) (string, error) {
+	htmlContent, err := LoadRecipientTemplate(comparison, charts, recipient)
 	if err != nil {
-		fmt.Println(err.Error())
-		return err
+		return "", err
+	}
+	// グラフを表示しない場合は画像も添付しない
+	var images []InlineImage
+	if recipient.Show(SectionCharts) {
+		images = charts.Images()
 	}
-	fmt.Println(sent.Id)
 
-	return nil
+	return sender.Send(context.Background(), Message{
+		From:    fromAddress(recipient.Language, emailDomain),
+		To:      []string{recipient.To()},
+		Cc:      recipient.Cc,
+		Bcc:     recipient.Bcc,
+		HTML:    htmlContent,
+		Text:    RenderRecipientText(comparison, recipient),
+		Inline:  images,
+		Subject: subject,
+
+		IdempotencyKey: key,
+	})
+}
+
+// チームと週ごとの名前（"alice+bob"、GitHub のユーザー名に + は使えないため個人と衝突しない）
+func TeamDeliveryName(report github.TeamReport) string {
+	var members []string
+	for _, member := range report.Members {
+		members = append(members, member.Username)
+	}
+	return strings.Join(members, "+")
 }
 
-func TestSend() error {
-	htmlByte, err := os.ReadFile("templates/dist/test.html")
+// チームダイジェストの週と送信先ごとの冪等キー（"team/alice+bob/2026-02-14/lead@example.com"）
+func TeamDeliveryKey(report github.TeamReport, address string) string {
+	return fmt.Sprintf("team/%s/%s/%s", TeamDeliveryName(report), report.Team.StartDate.Format("2006-01-02"), strings.ToLower(address))
+}
+
+// チームリーダー向けのダイジェストメール送信（送信サービス上のIDを返す）
+func SendTeamReport(sender Sender, htmlContent string, textContent string, lang i18n.Language, emailDomain string, emailTo string, key string) (string, error) {
+	return sender.Send(context.Background(), Message{
+		From:    fromAddress(lang, emailDomain),
+		To:      []string{emailTo},
+		HTML:    htmlContent,
+		Text:    textContent,
+		Subject: lang.T("email.subject.team", lang.FullDate(time.Now())),
+
+		IdempotencyKey: key,
+	})
+}
+
+// 送信元（送信者名は言語に合わせる）
+func fromAddress(lang i18n.Language, emailDomain string) string {
+	return lang.T("email.sender") + " <" + emailDomain + ">"
+}
+
+func TestSend(sender Sender) error {
+	htmlContent, err := renderTemplate(TemplateTest, nil)
 	if err != nil {
 		return err
 	}
-	apiKey := os.Getenv("RESEND_API_KEY")
 	emailDomain := os.Getenv("RESEND_EMAIL_DOMAIN")
 	emailTo := os.Getenv("TEST_RESEND_EMAIL_TO")
-	emailFrom := "Acme <" + emailDomain + ">"
-	client := resend.NewClient(apiKey)
 
-	params := &resend.SendEmailRequest{
-		From:    emailFrom,
+	_, err = sender.Send(context.Background(), Message{
+		From:    "Acme <" + emailDomain + ">",
 		To:      []string{emailTo},
 		Subject: "【Test】Weekly Log System Connection Check",
-		Html:    string(htmlByte),
-	}
-
-	_, err = client.Emails.Send(params)
+		HTML:    htmlContent,
+	})
 	return err
 }
 
-func TestWeeklyMailSend() error {
-
-	now := time.Now()
-	startDate := now.AddDate(0, 0, -7)
-	prevStartDate := now.AddDate(0, 0, -14)
-	weekdays := []string{"日", "月", "火", "水", "木", "金", "土"}
-
-	// モックデータ
-	stats := github.WeeklyStats{
-		TotalCommits: 42,
-		ActiveDays:   5,
-		StartDate:    startDate,
-		EndDate:      now,
-		DailyCommits: []github.DailyCommit{
-			{Date: startDate, DateStr: startDate.Format("1/2"), Weekday: weekdays[startDate.Weekday()], Count: 5},
-			{Date: startDate.AddDate(0, 0, 1), DateStr: startDate.AddDate(0, 0, 1).Format("1/2"), Weekday: weekdays[startDate.AddDate(0, 0, 1).Weekday()], Count: 12},
-			{Date: startDate.AddDate(0, 0, 2), DateStr: startDate.AddDate(0, 0, 2).Format("1/2"), Weekday: weekdays[startDate.AddDate(0, 0, 2).Weekday()], Count: 0},
-			{Date: startDate.AddDate(0, 0, 3), DateStr: startDate.AddDate(0, 0, 3).Format("1/2"), Weekday: weekdays[startDate.AddDate(0, 0, 3).Weekday()], Count: 8},
-			{Date: startDate.AddDate(0, 0, 4), DateStr: startDate.AddDate(0, 0, 4).Format("1/2"), Weekday: weekdays[startDate.AddDate(0, 0, 4).Weekday()], Count: 15},
-			{Date: startDate.AddDate(0, 0, 5), DateStr: startDate.AddDate(0, 0, 5).Format("1/2"), Weekday: weekdays[startDate.AddDate(0, 0, 5).Weekday()], Count: 2},
-			{Date: startDate.AddDate(0, 0, 6), DateStr: startDate.AddDate(0, 0, 6).Format("1/2"), Weekday: weekdays[startDate.AddDate(0, 0, 6).Weekday()], Count: 0},
-		},
-		RepoDetails: []github.RepoDetail{
-			{Name: "awesome-project", Count: 25, BarPercent: 100.0},
-			{Name: "go-utils", Count: 12, BarPercent: 48.0},
-			{Name: "dotfiles", Count: 5, BarPercent: 20.0},
-		},
-		MainLanguages: map[string]int{
-			"Go":         120,
-			"TypeScript": 85,
-			"Python":     30,
-		},
-	}
-
-	comparison := github.WeeklyComparison{
-		CurrentWeek: &stats,
-		PreviousWeek: &github.WeeklyStats{
-			TotalCommits: 30,
-			ActiveDays:   4,
-			StartDate:    prevStartDate,
-			EndDate:      now.AddDate(0, 0, -7),
-			DailyCommits: []github.DailyCommit{
-				{Date: prevStartDate, DateStr: prevStartDate.Format("1/2"), Weekday: weekdays[prevStartDate.Weekday()], Count: 3},
-				{Date: prevStartDate.AddDate(0, 0, 1), DateStr: prevStartDate.AddDate(0, 0, 1).Format("1/2"), Weekday: weekdays[prevStartDate.AddDate(0, 0, 1).Weekday()], Count: 8},
-				{Date: prevStartDate.AddDate(0, 0, 2), DateStr: prevStartDate.AddDate(0, 0, 2).Format("1/2"), Weekday: weekdays[prevStartDate.AddDate(0, 0, 2).Weekday()], Count: 0},
-				{Date: prevStartDate.AddDate(0, 0, 3), DateStr: prevStartDate.AddDate(0, 0, 3).Format("1/2"), Weekday: weekdays[prevStartDate.AddDate(0, 0, 3).Weekday()], Count: 12},
-				{Date: prevStartDate.AddDate(0, 0, 4), DateStr: prevStartDate.AddDate(0, 0, 4).Format("1/2"), Weekday: weekdays[prevStartDate.AddDate(0, 0, 4).Weekday()], Count: 7},
-				{Date: prevStartDate.AddDate(0, 0, 5), DateStr: prevStartDate.AddDate(0, 0, 5).Format("1/2"), Weekday: weekdays[prevStartDate.AddDate(0, 0, 5).Weekday()], Count: 0},
-				{Date: prevStartDate.AddDate(0, 0, 6), DateStr: prevStartDate.AddDate(0, 0, 6).Format("1/2"), Weekday: weekdays[prevStartDate.AddDate(0, 0, 6).Weekday()], Count: 0},
-			},
-			RepoDetails: []github.RepoDetail{
-				{Name: "awesome-project", Count: 18, BarPercent: 100.0},
-				{Name: "go-utils", Count: 8, BarPercent: 44.4},
-				{Name: "dotfiles", Count: 4, BarPercent: 22.2},
-			},
-			MainLanguages: map[string]int{
-				"Go":         90,
-				"TypeScript": 60,
-				"Python":     20,
-			},
-		},
-		CommitsDiff:       12,
-		CommitsChangeRate: 40,
-	}
-
-	tmepl, err := template.ParseFiles("templates/dist/weekly.html")
+func TestWeeklyMailSend(sender Sender) error {
+	comparison := SampleWeeklyComparison(time.Now())
+	charts, err := RenderCharts(comparison)
 	if err != nil {
 		return err
 	}
 
-	var buf bytes.Buffer
-	err = tmepl.Execute(&buf, comparison)
+	htmlContent, err := LoadTemplateWithCharts(comparison, charts)
 	if err != nil {
 		return err
 	}
 
-	apiKey := os.Getenv("RESEND_API_KEY")
 	emailDomain := os.Getenv("RESEND_EMAIL_DOMAIN_DEV")
 	emailTo := os.Getenv("TEST_RESEND_EMAIL_TO")
 
-	client := resend.NewClient(apiKey)
-	subject := fmt.Sprintf("[テスト]週間コミットレポート (%s)", time.Now().Format("2006/01/02"))
-	params := &resend.SendEmailRequest{
-		From:    "[テスト]お疲れ様委員会 <" + emailDomain + ">",
+	lang := i18n.Default
+	subject := "[テスト]" + lang.T("email.subject.weekly", lang.FullDate(time.Now()))
+	msg := Message{
+		From:    `"[テスト]` + lang.T("email.sender") + `" <` + emailDomain + ">", // [] は引用符で囲む
 		To:      []string{emailTo},
-		Html:    buf.String(),
+		HTML:    htmlContent,
+		Text:    RenderText(comparison),
+		Inline:  charts.Images(),
 		Subject: subject,
 	}
-	_, err = client.Emails.Send(params)
+	_, err = sender.Send(context.Background(), msg)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,188 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github-weekly-log/internal/i18n"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTP の接続方式
+const (
+	SMTPSecuritySTARTTLS = "starttls" // 平文で接続して STARTTLS で暗号化（既定・587番）
+	SMTPSecurityTLS      = "tls"      // 接続時から TLS（465番）
+	SMTPSecurityNone     = "none"     // 暗号化しない（25番、ローカルの中継サーバー向け）
+)
+
+// SMTP サーバーへの接続のタイムアウト
+const smtpDialTimeout = 30 * time.Second
+
+// 接続してから送信を終えるまでのタイムアウトの既定値（ctx に期限がない場合）
+const smtpSessionTimeout = 2 * time.Minute
+
+// SMTP サーバーの設定
+type SMTPConfig struct {
+	Host      string        // ホスト名
+	Port      int           // ポート番号（0 の場合は接続方式ごとの既定値）
+	Username  string        // 認証ユーザー（空の場合は認証しない）
+	Password  string        // 認証パスワード
+	Security  string        // 接続方式（空の場合は SMTPSecuritySTARTTLS）
+	TLSConfig *tls.Config   // TLS の設定（nil の場合はホスト名で証明書を検証）
+	Timeout   time.Duration // ctx に期限がない場合の送信全体のタイムアウト（0 の場合は 2分）
+}
+
+// SMTP サーバー経由で送信する
+type SMTPSender struct {
+	cfg SMTPConfig
+}
+
+// SMTP の送信方法を生成
+func NewSMTPSender(cfg SMTPConfig) (*SMTPSender, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("SMTP のホストが未設定です")
+	}
+
+	cfg.Security = strings.ToLower(cfg.Security)
+	defaultPort := map[string]int{
+		"":                   587,
+		SMTPSecuritySTARTTLS: 587,
+		SMTPSecurityTLS:      465,
+		SMTPSecurityNone:     25,
+	}
+	port, exists := defaultPort[cfg.Security]
+	if !exists {
+		return nil, fmt.Errorf("未対応の SMTP 接続方式です: %q", cfg.Security)
+	}
+	if cfg.Security == "" {
+		cfg.Security = SMTPSecuritySTARTTLS
+	}
+	if cfg.Port == 0 {
+		cfg.Port = port
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = smtpSessionTimeout
+	}
+
+	return &SMTPSender{cfg: cfg}, nil
+}
+
+// TLS の設定（ServerName を補完する）
+func (s *SMTPSender) tlsConfig() *tls.Config {
+	if s.cfg.TLSConfig == nil {
+		return &tls.Config{ServerName: s.cfg.Host}
+	}
+	config := s.cfg.TLSConfig.Clone()
+	if config.ServerName == "" {
+		config.ServerName = s.cfg.Host
+	}
+	return config
+}
+
+// メールを送信し、生成した Message-ID を返す
+func (s *SMTPSender) Send(ctx context.Context, msg Message) (string, error) {
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return "", permanent(fmt.Errorf("送信元のアドレスが不正です: %w", err))
+	}
+	if len(msg.To) == 0 {
+		return "", permanent(fmt.Errorf("送信先が未設定です"))
+	}
+
+	messageID := newMessageID(from.Address, msg.IdempotencyKey)
+	body, err := buildMIMEMessage(msg, messageID, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	client, err := s.dial(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	if s.cfg.Security == SMTPSecuritySTARTTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return "", fmt.Errorf("SMTP サーバーが STARTTLS に対応していません")
+		}
+		if err := client.StartTLS(s.tlsConfig()); err != nil {
+			return "", fmt.Errorf("STARTTLS に失敗しました: %w", err)
+		}
+	}
+
+	if s.cfg.Username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return "", fmt.Errorf("SMTP サーバーが認証に対応していません")
+		}
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return "", fmt.Errorf("SMTP 認証に失敗しました: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return "", fmt.Errorf("MAIL FROM に失敗しました: %w", err)
+	}
+	// Bcc はヘッダーに含めず、エンベロープの宛先にのみ追加する
+	for _, to := range slices.Concat(msg.To, msg.Cc, msg.Bcc) {
+		addr, err := mail.ParseAddress(to)
+		if err != nil {
+			return "", permanent(fmt.Errorf("送信先のアドレスが不正です: %w", err))
+		}
+		if err := client.Rcpt(addr.Address); err != nil {
+			return "", fmt.Errorf("RCPT TO に失敗しました (%s): %w", addr.Address, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return "", fmt.Errorf("DATA に失敗しました: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return "", fmt.Errorf("本文の送信に失敗しました: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("本文の送信に失敗しました: %w", err)
+	}
+
+	// DATA が受理された時点で送信は完了しているため、QUIT の失敗で再送しない（再送すると二重に届く）
+	if err := client.Quit(); err != nil {
+		i18n.Println("console.smtp_quit_failed", err)
+	}
+	return messageID, nil
+}
+
+// SMTP サーバーに接続する（implicit TLS の場合は接続時に TLS を開始）
+func (s *SMTPSender) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	dialer := &net.Dialer{Timeout: smtpDialTimeout}
+
+	var conn net.Conn
+	var err error
+	if s.cfg.Security == SMTPSecurityTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: s.tlsConfig()}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SMTP サーバーへの接続に失敗しました: %w", err)
+	}
+	// 応答しないサーバーで止まらないよう、ctx に期限がない場合もタイムアウトを設ける
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(s.cfg.Timeout)
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("SMTP セッションの開始に失敗しました: %w", err)
+	}
+	return client, nil
+}
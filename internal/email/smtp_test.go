@@ -0,0 +1,503 @@
+package email
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// テスト用のSMTPサーバーが受信したメール
+type receivedMail struct {
+	auth string   // AUTH PLAIN で受け取った "ユーザー:パスワード"
+	tls  bool     // TLS で受信したか
+	from string   // MAIL FROM
+	to   []string // RCPT TO
+	data []byte   // DATA
+}
+
+// テスト用のSMTPサーバー（1接続ずつ処理する）
+type testSMTPServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config // nil の場合は STARTTLS を提供しない
+	implicit  bool        // 接続時から TLS
+	failQuit  bool        // QUIT にエラーを返す
+	received  chan receivedMail
+}
+
+// テスト用のSMTPサーバーを起動
+func startTestSMTPServer(t *testing.T, tlsConfig *tls.Config, implicit bool) *testSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	if implicit {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	server := &testSMTPServer{listener: listener, tlsConfig: tlsConfig, implicit: implicit, received: make(chan receivedMail, 1)}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+// SMTPサーバーのポート番号
+func (s *testSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// 1接続分のSMTPセッションを処理
+func (s *testSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	session := receivedMail{tls: s.implicit}
+	text.PrintfLine("220 localhost ESMTP test")
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		command, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(command) {
+		case "EHLO", "HELO":
+			lines := []string{"localhost"}
+			if s.tlsConfig != nil && !session.tls {
+				lines = append(lines, "STARTTLS")
+			}
+			lines = append(lines, "AUTH PLAIN")
+			for i, l := range lines {
+				sep := "-"
+				if i == len(lines)-1 {
+					sep = " "
+				}
+				text.PrintfLine("250%s%s", sep, l)
+			}
+		case "STARTTLS":
+			text.PrintfLine("220 ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			text = textproto.NewConn(conn)
+			session = receivedMail{tls: true}
+		case "AUTH":
+			_, encoded, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(encoded)
+			parts := strings.Split(string(decoded), "\x00")
+			if len(parts) == 3 {
+				session.auth = parts[1] + ":" + parts[2]
+			}
+			text.PrintfLine("235 authenticated")
+		case "MAIL":
+			session.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			text.PrintfLine("250 ok")
+		case "RCPT":
+			session.to = append(session.to, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			text.PrintfLine("250 ok")
+		case "DATA":
+			text.PrintfLine("354 go ahead")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			session.data = data
+			text.PrintfLine("250 queued")
+			s.received <- session
+		case "QUIT":
+			if s.failQuit {
+				text.PrintfLine("421 shutting down")
+				return
+			}
+			text.PrintfLine("221 bye")
+			return
+		default:
+			text.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+// テスト用の自己署名証明書（127.0.0.1）を生成し、サーバー・クライアントの TLS 設定を返す
+func newTestTLSConfig(t *testing.T) (*tls.Config, *tls.Config) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	server := &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	client := &tls.Config{RootCAs: pool}
+	return server, client
+}
+
+// 受信したメールを待つ
+func waitReceived(t *testing.T, server *testSMTPServer) receivedMail {
+	t.Helper()
+	select {
+	case received := <-server.received:
+		return received
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for mail")
+		return receivedMail{}
+	}
+}
+
+// 受信したメールのヘッダーと Content-Type ごとの本文を取り出す
+func parseReceivedMail(t *testing.T, data []byte) (*mail.Message, map[string]string) {
+	t.Helper()
+	msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("read message: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type: expected multipart/alternative, got %q (%v)", mediaType, err)
+	}
+
+	bodies := make(map[string]string)
+	var order []string
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("next part: %v", err)
+		}
+		contentType, partParams, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if contentType == "multipart/related" {
+			// HTML とインライン画像（画像は "cid:<Content-ID>" をキーにする）
+			readRelatedParts(t, part, partParams["boundary"], bodies)
+			order = append(order, "text/html")
+			continue
+		}
+		// multipart.Reader は quoted-printable を自動でデコードする
+		body, _ := io.ReadAll(part)
+		bodies[contentType] = string(body)
+		order = append(order, contentType)
+	}
+	if len(order) == 0 || order[len(order)-1] != "text/html" {
+		t.Fatalf("expected text/html as the last part, got %v", order)
+	}
+	return msg, bodies
+}
+
+// multipart/related の各パートを取り出す（先頭は HTML）
+func readRelatedParts(t *testing.T, r io.Reader, boundary string, bodies map[string]string) {
+	t.Helper()
+	reader := multipart.NewReader(r, boundary)
+	for i := 0; ; i++ {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("next related part: %v", err)
+		}
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(part)
+		if i == 0 {
+			if contentType != "text/html" {
+				t.Fatalf("expected text/html as the first related part, got %q", contentType)
+			}
+			bodies[contentType] = string(body)
+			continue
+		}
+		if part.Header.Get("Content-Transfer-Encoding") != "base64" {
+			t.Fatalf("inline image: expected base64, got %q", part.Header.Get("Content-Transfer-Encoding"))
+		}
+		data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(body), "\r\n", ""))
+		if err != nil {
+			t.Fatalf("decode inline image: %v", err)
+		}
+		contentID := strings.Trim(part.Header.Get("Content-ID"), "<>")
+		bodies["cid:"+contentID] = string(data)
+	}
+}
+
+// テスト: インライン画像は HTML と multipart/related にまとめる
+func TestBuildMIMEMessageInline(t *testing.T) {
+	image := InlineImage{ContentID: "daily-chart", Filename: "daily-chart.png", ContentType: "image/png", Data: []byte(strings.Repeat("\x89PNG\x00\xff", 30))}
+	data, err := buildMIMEMessage(Message{
+		From:    "report@example.com",
+		To:      []string{"me@example.com"},
+		Subject: "report",
+		HTML:    `<img src="cid:daily-chart">`,
+		Text:    "report",
+		Inline:  []InlineImage{image},
+	}, "<id@example.com>", time.Now())
+	if err != nil {
+		t.Fatalf("buildMIMEMessage: %v", err)
+	}
+	for _, line := range strings.Split(string(data), "\r\n") {
+		if len(line) > 998 {
+			t.Fatalf("line too long (%d): %q", len(line), line)
+		}
+	}
+
+	_, bodies := parseReceivedMail(t, data)
+	if bodies["text/plain"] != "report" || bodies["text/html"] != `<img src="cid:daily-chart">` {
+		t.Errorf("bodies: got %q", bodies)
+	}
+	if bodies["cid:daily-chart"] != string(image.Data) {
+		t.Errorf("inline image mismatch")
+	}
+}
+
+// テスト: STARTTLS・認証ありでの送信
+func TestSMTPSenderSTARTTLS(t *testing.T) {
+	serverTLS, clientTLS := newTestTLSConfig(t)
+	server := startTestSMTPServer(t, serverTLS, false)
+
+	sender, err := NewSMTPSender(SMTPConfig{
+		Host:      "127.0.0.1",
+		Port:      server.port(),
+		Username:  "user",
+		Password:  "secret",
+		TLSConfig: clientTLS,
+	})
+	if err != nil {
+		t.Fatalf("NewSMTPSender: %v", err)
+	}
+
+	html := "<p>今週は 42 コミットでした</p>" + strings.Repeat("<span>長い行</span>", 20)
+	text := "総コミット数: 42\n  awesome-project    25\n"
+	id, err := sender.Send(context.Background(), Message{
+		From:    "お疲れ様委員会 <report@example.com>",
+		To:      []string{"me@example.com"},
+		Subject: "週間コミットレポート (2026/02/21)",
+		HTML:    html,
+		Text:    text,
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	received := waitReceived(t, server)
+	if !received.tls {
+		t.Errorf("expected STARTTLS to be used")
+	}
+	if received.auth != "user:secret" {
+		t.Errorf("auth: expected user:secret, got %q", received.auth)
+	}
+	if received.from != "report@example.com" || len(received.to) != 1 || received.to[0] != "me@example.com" {
+		t.Errorf("envelope: got from=%q to=%v", received.from, received.to)
+	}
+
+	msg, bodies := parseReceivedMail(t, received.data)
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "週間コミットレポート (2026/02/21)" {
+		t.Errorf("Subject: got %q", subject)
+	}
+	from, err := msg.Header.AddressList("From")
+	if err != nil || from[0].Name != "お疲れ様委員会" {
+		t.Errorf("From: got %v (%v)", from, err)
+	}
+	if msg.Header.Get("Message-ID") != id || !strings.HasSuffix(id, "@example.com>") {
+		t.Errorf("Message-ID: expected %q, got %q", id, msg.Header.Get("Message-ID"))
+	}
+	if bodies["text/html"] != html {
+		t.Errorf("HTML body mismatch:\n got: %q\nwant: %q", bodies["text/html"], html)
+	}
+	if bodies["text/plain"] != text {
+		t.Errorf("text body mismatch:\n got: %q\nwant: %q", bodies["text/plain"], text)
+	}
+}
+
+// テスト: 接続時から TLS での送信
+func TestSMTPSenderImplicitTLS(t *testing.T) {
+	serverTLS, clientTLS := newTestTLSConfig(t)
+	server := startTestSMTPServer(t, serverTLS, true)
+
+	sender, err := NewSMTPSender(SMTPConfig{
+		Host:      "127.0.0.1",
+		Port:      server.port(),
+		Security:  SMTPSecurityTLS,
+		TLSConfig: clientTLS,
+	})
+	if err != nil {
+		t.Fatalf("NewSMTPSender: %v", err)
+	}
+
+	_, err = sender.Send(context.Background(), Message{
+		From:    "report@example.com",
+		To:      []string{"a@example.com", "B <b@example.com>"},
+		Cc:      []string{"c@example.com"},
+		Bcc:     []string{"hidden@example.com"},
+		Subject: "report",
+		HTML:    "<p>hi</p>",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	received := waitReceived(t, server)
+	if !received.tls || received.auth != "" {
+		t.Errorf("expected TLS without auth, got tls=%v auth=%q", received.tls, received.auth)
+	}
+	if strings.Join(received.to, ",") != "a@example.com,b@example.com,c@example.com,hidden@example.com" {
+		t.Errorf("RCPT TO: got %v", received.to)
+	}
+
+	// BCC はヘッダーに含めない
+	msg, _ := parseReceivedMail(t, received.data)
+	if msg.Header.Get("Cc") != "<c@example.com>" {
+		t.Errorf("Cc: got %q", msg.Header.Get("Cc"))
+	}
+	if strings.Contains(string(received.data), "hidden@example.com") {
+		t.Errorf("expected Bcc to be omitted from headers")
+	}
+}
+
+// テスト: STARTTLS に対応していないサーバーには送信しない
+func TestSMTPSenderRequiresSTARTTLS(t *testing.T) {
+	server := startTestSMTPServer(t, nil, false)
+
+	sender, err := NewSMTPSender(SMTPConfig{Host: "127.0.0.1", Port: server.port()})
+	if err != nil {
+		t.Fatalf("NewSMTPSender: %v", err)
+	}
+	_, err = sender.Send(context.Background(), Message{From: "report@example.com", To: []string{"me@example.com"}, HTML: "x"})
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Errorf("expected STARTTLS error, got %v", err)
+	}
+}
+
+// テスト: 本文が受理された後に QUIT が失敗しても送信済みとして扱い、再送しない
+func TestSMTPSenderQuitFailure(t *testing.T) {
+	server := startTestSMTPServer(t, nil, false)
+	server.failQuit = true
+
+	sender, err := NewSMTPSender(SMTPConfig{Host: "127.0.0.1", Port: server.port(), Security: SMTPSecurityNone})
+	if err != nil {
+		t.Fatalf("NewSMTPSender: %v", err)
+	}
+	retry, waits := newTestRetrySender(sender, RetryConfig{Attempts: 3, BaseDelay: time.Millisecond})
+	id, err := retry.Send(context.Background(), Message{From: "report@example.com", To: []string{"me@example.com"}, HTML: "x"})
+	if err != nil || id == "" {
+		t.Fatalf("expected success, got %q (%v)", id, err)
+	}
+	if len(*waits) != 0 || len(server.received) != 1 {
+		t.Errorf("expected a single delivery, got %d waits and %d mails", len(*waits), len(server.received))
+	}
+}
+
+// テスト: 応答しないサーバーはタイムアウトする
+func TestSMTPSenderTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		// 接続を受け付けるだけで挨拶を返さない
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+
+	sender, err := NewSMTPSender(SMTPConfig{Host: "127.0.0.1", Port: listener.Addr().(*net.TCPAddr).Port, Timeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewSMTPSender: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := sender.Send(context.Background(), Message{From: "report@example.com", To: []string{"me@example.com"}, HTML: "x"})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Errorf("expected timeout error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected Send to time out")
+	}
+}
+
+// テスト: 送信方法の生成
+func TestNewSender(t *testing.T) {
+	// 送信方法は再試行付きで生成される
+	unwrap := func(sender Sender) Sender {
+		retry, ok := sender.(*RetrySender)
+		if !ok {
+			t.Fatalf("expected *RetrySender, got %T", sender)
+		}
+		return retry.sender
+	}
+
+	if sender, err := NewSender(Config{ResendAPIKey: "re_test"}); err != nil {
+		t.Errorf("default provider: %v", err)
+	} else if _, ok := unwrap(sender).(*ResendSender); !ok {
+		t.Errorf("default provider: expected *ResendSender, got %T", unwrap(sender))
+	}
+
+	sender, err := NewSender(Config{Provider: "SMTP", SMTP: SMTPConfig{Host: "smtp.example.com", Security: "tls"}, Retry: RetryConfig{Attempts: 5}})
+	if err != nil {
+		t.Fatalf("smtp provider: %v", err)
+	}
+	if smtpSender, ok := unwrap(sender).(*SMTPSender); !ok || smtpSender.cfg.Port != 465 {
+		t.Errorf("smtp provider: expected *SMTPSender on port 465, got %T %+v", sender, sender)
+	}
+	if retry := sender.(*RetrySender); retry.cfg.Attempts != 5 || retry.cfg.BaseDelay != defaultRetryBaseDelay {
+		t.Errorf("retry config: got %+v", retry.cfg)
+	}
+
+	for _, cfg := range []Config{
+		{Provider: "sendgrid"},
+		{Provider: ProviderSMTP},
+		{Provider: ProviderSMTP, SMTP: SMTPConfig{Host: "smtp.example.com", Security: "ssl"}},
+	} {
+		if _, err := NewSender(cfg); err == nil {
+			t.Errorf("%+v: expected error", cfg)
+		}
+	}
+}
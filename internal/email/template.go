@@ -0,0 +1,165 @@
+package email
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github-weekly-log/internal/i18n"
+	"github-weekly-log/templates"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// テンプレートの種類
+const (
+	TemplateWeekly = "weekly" // 週間レポート
+	TemplateTeam   = "team"   // チームダイジェスト
+	TemplateTest   = "test"   // 接続確認用
+)
+
+// 登録済みのテンプレート
+type templateEntry struct {
+	file   string     // templates/dist 以下のファイル名
+	sample func() any // 起動時の検証に使うサンプルデータ
+}
+
+// テンプレートの一覧（新しいテンプレートはここに追加する）
+// monthly / alert は月次集計・通知の送信処理がないため未登録
+var templateRegistry = map[string]templateEntry{
+	TemplateWeekly: {file: "weekly.html", sample: func() any {
+		comparison := SampleWeeklyComparison(time.Now())
+		charts, _ := RenderCharts(comparison)
+		return weeklyTemplateData{WeeklyComparison: comparison, Charts: charts, Lang: i18n.Default}
+	}},
+	TemplateTeam: {file: "team.html", sample: func() any {
+		return teamTemplateData{TeamReport: SampleTeamReport(time.Now()), Lang: i18n.Default}
+	}},
+	TemplateTest: {file: "test.html", sample: func() any { return nil }},
+}
+
+// 埋め込みのテンプレート
+var embeddedTemplates = func() fs.FS {
+	dist, err := fs.Sub(templates.Dist, "dist")
+	if err != nil {
+		panic(err)
+	}
+	return dist
+}()
+
+// テンプレートの読み込み元（SetTemplateDir で上書き）
+var templateFS = embeddedTemplates
+
+// テンプレートを上書きするディレクトリを設定する（空の場合は埋め込みのみ）
+// ディレクトリにないテンプレートは埋め込みのものを使う
+func SetTemplateDir(dir string) error {
+	if dir == "" {
+		templateFS = embeddedTemplates
+		return nil
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("テンプレートディレクトリを開けません: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("テンプレートディレクトリではありません: %s", dir)
+	}
+	templateFS = overlayFS{top: os.DirFS(dir), base: embeddedTemplates}
+	return nil
+}
+
+// top にあるファイルを優先し、なければ base から読む
+type overlayFS struct {
+	top  fs.FS
+	base fs.FS
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	file, err := o.top.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return o.base.Open(name)
+	}
+	return file, err
+}
+
+// 登録済みのテンプレート名（名前順）
+func TemplateNames() []string {
+	names := make([]string, 0, len(templateRegistry))
+	for name := range templateRegistry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// 全テンプレートを読み込み、サンプルデータで実行できるか検証する
+func ValidateTemplates() error {
+	var errs []error
+	for _, name := range TemplateNames() {
+		if err := executeTemplate(io.Discard, name, templateRegistry[name].sample()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// 登録済みのテンプレートにデータを埋め込む
+func renderTemplate(name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := executeTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// テンプレートを読み込み、データを埋め込んで書き出す
+func executeTemplate(w io.Writer, name string, data any) error {
+	entry, exists := templateRegistry[name]
+	if !exists {
+		return fmt.Errorf("未登録のテンプレートです: %q（%s）", name, strings.Join(TemplateNames(), ", "))
+	}
+
+	source, err := fs.ReadFile(templateFS, entry.file)
+	if err != nil {
+		return fmt.Errorf("テンプレート読み込み失敗 (%s): %w", name, err)
+	}
+	body, comments := preserveComments(string(source))
+	tmpl, err := template.New(entry.file).Funcs(templateFuncs).Funcs(comments).Parse(body)
+	if err != nil {
+		return fmt.Errorf("テンプレート読み込み失敗 (%s): %w", name, err)
+	}
+	// Executeでデータを埋め込む
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("テンプレート実行失敗 (%s): %w", name, err)
+	}
+	return nil
+}
+
+// HTML コメント（Outlook 向けの条件付きコメントなど）
+var htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// html/template はコメントを削除するため、コメントをそのまま出力するアクションに置き換える
+// MJML が出力する条件付きコメント（<!--[if mso | IE]>...<![endif]-->）は Outlook の表示に必要
+// 出力できるのはテンプレート内のコメントのみで、データをエスケープせずに出力することはできない
+func preserveComments(source string) (string, template.FuncMap) {
+	var comments []template.HTML
+	body := htmlCommentPattern.ReplaceAllStringFunc(source, func(comment string) string {
+		comments = append(comments, template.HTML(comment))
+		return "{{htmlComment " + strconv.Itoa(len(comments)-1) + "}}"
+	})
+	return body, template.FuncMap{
+		// 置き換えた順番のコメントを出力する
+		"htmlComment": func(index int) (template.HTML, error) {
+			if index < 0 || index >= len(comments) {
+				return "", fmt.Errorf("コメントがありません: %d", index)
+			}
+			return comments[index], nil
+		},
+	}
+}
@@ -0,0 +1,182 @@
+package email
+
+import (
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// テスト用にテンプレートの上書き先を設定し、終了時に埋め込みへ戻す
+func setTestTemplateDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("write template: %v", err)
+		}
+	}
+	if err := SetTemplateDir(dir); err != nil {
+		t.Fatalf("SetTemplateDir: %v", err)
+	}
+	t.Cleanup(func() { SetTemplateDir("") })
+	return dir
+}
+
+// テスト: 埋め込みのテンプレートはすべてサンプルデータで実行できる
+func TestValidateTemplates(t *testing.T) {
+	if err := ValidateTemplates(); err != nil {
+		t.Fatalf("ValidateTemplates: %v", err)
+	}
+	if names := TemplateNames(); strings.Join(names, ",") != "team,test,weekly" {
+		t.Errorf("TemplateNames: got %v", names)
+	}
+
+	html, err := LoadTemplate(SampleWeeklyComparison(time.Now()))
+	if err != nil {
+		t.Fatalf("LoadTemplate: %v", err)
+	}
+	if !strings.Contains(html, "awesome-project") {
+		t.Errorf("expected rendered repository name")
+	}
+}
+
+// テスト: 上書き先にあるテンプレートだけを差し替える
+func TestSetTemplateDir(t *testing.T) {
+	setTestTemplateDir(t, map[string]string{"weekly.html": "{{.CurrentWeek.Username}} さんの週間レポート"})
+
+	html, err := LoadTemplate(SampleWeeklyComparison(time.Now()))
+	if err != nil {
+		t.Fatalf("LoadTemplate: %v", err)
+	}
+	if html != "mizunoryuki さんの週間レポート" {
+		t.Errorf("expected overridden template, got %q", html)
+	}
+
+	team, err := LoadTeamTemplate(SampleTeamReport(time.Now()), i18n.Japanese)
+	if err != nil {
+		t.Fatalf("LoadTeamTemplate: %v", err)
+	}
+	if !strings.Contains(team, "alice") {
+		t.Errorf("expected embedded team template")
+	}
+
+	if err := SetTemplateDir(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("expected error for missing directory")
+	}
+}
+
+// テスト: 読み込み・実行できないテンプレートは検証で失敗する
+func TestValidateTemplatesError(t *testing.T) {
+	setTestTemplateDir(t, map[string]string{
+		"weekly.html": "{{.CurrentWeek.Unknown}}",
+		"team.html":   "{{if .Team}}",
+	})
+
+	err := ValidateTemplates()
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{"テンプレート実行失敗 (weekly)", "テンプレート読み込み失敗 (team)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+	if _, err := renderTemplate("monthly", nil); err == nil {
+		t.Errorf("expected error for unknown template")
+	}
+}
+
+// テスト: データは HTML としてエスケープし、条件付きコメントは残す
+func TestTemplateEscaping(t *testing.T) {
+	setTestTemplateDir(t, map[string]string{
+		"weekly.html": `<!--[if mso | IE]><table><tr><td><![endif]-->{{range .CurrentWeek.RepoDetails}}<a href="https://github.com/{{.Name}}">{{.Name}}</a>{{end}}<!--[if mso | IE]></td></tr></table><![endif]-->`,
+	})
+
+	comparison := SampleWeeklyComparison(time.Now())
+	comparison.CurrentWeek.RepoDetails = []github.RepoDetail{{Name: `<script>alert("x")</script>`}}
+	html, err := LoadTemplate(comparison)
+	if err != nil {
+		t.Fatalf("LoadTemplate: %v", err)
+	}
+	if strings.Contains(html, "<script>") || !strings.Contains(html, "&lt;script&gt;") {
+		t.Errorf("expected escaped repository name, got %q", html)
+	}
+	if !strings.HasPrefix(html, "<!--[if mso | IE]><table><tr><td><![endif]-->") || !strings.HasSuffix(html, "<!--[if mso | IE]></td></tr></table><![endif]-->") {
+		t.Errorf("expected conditional comments to be kept, got %q", html)
+	}
+
+	// テンプレートからデータをエスケープせずに出力することはできない
+	setTestTemplateDir(t, map[string]string{"weekly.html": `{{range .CurrentWeek.RepoDetails}}{{htmlComment .Name}}{{end}}`})
+	if html, err := LoadTemplate(comparison); err == nil {
+		t.Errorf("expected error for htmlComment with data, got %q", html)
+	}
+}
+
+// テスト: 送信先の言語に合わせて HTML を出し分ける
+func TestTemplateLanguage(t *testing.T) {
+	comparison := SampleWeeklyComparison(time.Now())
+
+	html, err := LoadRecipientTemplate(comparison, nil, Recipient{Language: i18n.English})
+	if err != nil {
+		t.Fatalf("LoadRecipientTemplate: %v", err)
+	}
+	for _, want := range []string{`lang="en"`, "Repository activity", "Mon"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected %q in English report", want)
+		}
+	}
+	if strings.Contains(html, "リポジトリ別活動") {
+		t.Errorf("expected no Japanese headings in English report")
+	}
+
+	team, err := LoadTeamTemplate(SampleTeamReport(time.Now()), i18n.English)
+	if err != nil {
+		t.Fatalf("LoadTeamTemplate: %v", err)
+	}
+	if !strings.Contains(team, "Activity by member") {
+		t.Errorf("expected English team report")
+	}
+}
+
+// テスト: 翻訳するテンプレートの本文に、カタログを通さない英語の文言が残っていない
+func TestTemplateUntranslatedLiterals(t *testing.T) {
+	// 固有名詞とコミットの種類は翻訳しない
+	allowed := []string{"GitHub", "feat", "fix"}
+	strip := regexp.MustCompile(`(?s)<style.*?</style>|<!--.*?-->|\{\{.*?\}\}|<[^>]*>|&[a-z]+;`)
+	word := regexp.MustCompile(`[A-Za-z][A-Za-z-]+`)
+
+	for _, name := range []string{TemplateWeekly, TemplateTeam} {
+		source, err := fs.ReadFile(embeddedTemplates, templateRegistry[name].file)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		text := strip.ReplaceAllString(string(source), " ")
+		for _, literal := range word.FindAllString(text, -1) {
+			if !slices.Contains(allowed, literal) {
+				t.Errorf("%s: untranslated literal %q", name, literal)
+			}
+		}
+		// タイトル（aria-label を含む）もカタログから表示する
+		if strings.Contains(string(source), "Weekly") {
+			t.Errorf("%s: untranslated title", name)
+		}
+	}
+
+	// 日本語のレポートに英語の単位が混ざらない
+	html, err := LoadRecipientTemplate(SampleWeeklyComparison(time.Now()), nil, Recipient{Language: i18n.Japanese})
+	if err != nil {
+		t.Fatalf("LoadRecipientTemplate: %v", err)
+	}
+	for _, literal := range []string{"/ 7 days", ">Private<", " commits · ", "files changed", "Sent by"} {
+		if strings.Contains(html, literal) {
+			t.Errorf("expected no %q in Japanese report", literal)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package email
+
+import (
+	"cmp"
+	"fmt"
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// テキスト版の区切り線
+var textRule = strings.Repeat("=", 40)
+
+// 週間レポートのテキスト版（text/plain パート用）を生成
+func RenderText(comparison github.WeeklyComparison) string {
+	return RenderRecipientText(comparison, Recipient{})
+}
+
+// 送信先に合わせて（宛名・表示するセクション・言語）週間レポートのテキスト版を生成
+func RenderRecipientText(comparison github.WeeklyComparison, recipient Recipient) string {
+	lang := recipient.Language.OrDefault()
+	current := comparison.CurrentWeek
+	previous := comparison.PreviousWeek
+	if previous == nil {
+		// 先週のデータがない JSON から読み込んだ場合は、先週のコミットがなかったものとして表示する
+		previous = &github.WeeklyStats{}
+	}
+	var b strings.Builder
+
+	b.WriteString(textRule + "\n")
+	b.WriteString(lang.T("report.title") + "\n")
+	b.WriteString(textRule + "\n\n")
+
+	if recipient.Name != "" {
+		b.WriteString(lang.T("report.greeting", recipient.Name) + "\n\n")
+	}
+	b.WriteString(lang.T("text.period", lang.FullDate(current.StartDate), lang.FullDate(current.EndDate)) + "\n")
+	b.WriteString(lang.T("text.total_commits", current.TotalCommits, previous.TotalCommits, formatChange(lang, comparison.CommitsDiff, comparison.CommitsChangeRate)) + "\n")
+	b.WriteString(lang.T("text.active_days", current.ActiveDays) + "\n")
+
+	if streaks := comparison.Streaks; streaks != nil && recipient.Show(SectionStreaks) {
+		b.WriteString(lang.T("text.streak_days", streaks.CurrentDays, streaks.LongestDays) + "\n")
+		b.WriteString(lang.T("text.streak_weeks", streaks.CurrentWeeks, streaks.LongestWeeks) + "\n")
+	}
+
+	// 日別のコミット数
+	if recipient.Show(SectionDaily) {
+		writeHeading(&b, lang.T("text.daily"))
+		days := [][]string{}
+		for _, day := range current.DailyCommits {
+			days = append(days, []string{day.DateStr, lang.Weekday(day.Date.Weekday()), fmt.Sprint(day.Count)})
+		}
+		writeTable(&b, []string{lang.T("text.date"), lang.T("text.weekday"), lang.T("text.commits")}, days, 2)
+	}
+
+	// 目標
+	if len(current.Goals) > 0 && recipient.Show(SectionGoals) {
+		hitRates := make(map[string]github.GoalSummary)
+		for _, summary := range comparison.GoalSummaries {
+			hitRates[summary.Metric] = summary
+		}
+		writeHeading(&b, lang.T("report.goals"))
+		rows := [][]string{}
+		for _, goal := range current.Goals {
+			mark := lang.T("text.not_achieved")
+			if goal.Achieved {
+				mark = lang.T("text.achieved")
+			}
+			rate := ""
+			if summary, exists := hitRates[goal.Metric]; exists {
+				rate = lang.T("text.goal_hit_rate", summary.HitRate, summary.Achieved, summary.Weeks)
+			}
+			rows = append(rows, []string{lang.Label("goal", goal.Metric), mark, fmt.Sprintf("%d / %d", goal.Actual, goal.Target), rate})
+		}
+		writeTable(&b, []string{lang.T("text.goal"), lang.T("text.status"), lang.T("text.actual"), lang.T("text.hit_rate")}, rows, 2)
+	}
+
+	// 今週の成果
+	if len(current.Shipped) > 0 && recipient.Show(SectionShipped) {
+		writeHeading(&b, lang.T("report.shipped"))
+		for _, repo := range current.Shipped {
+			fmt.Fprintf(&b, "  %s\n", repo.Repo)
+			for _, item := range repo.Items {
+				scope := ""
+				if item.Scope != "" {
+					scope = "(" + item.Scope + ")"
+				}
+				fmt.Fprintf(&b, "    - %s%s: %s\n", item.Type, scope, item.Subject)
+			}
+		}
+	}
+
+	// ハイライト
+	if len(current.Highlights) > 0 && recipient.Show(SectionHighlights) {
+		writeHeading(&b, lang.T("report.highlights"))
+		for _, repo := range current.Highlights {
+			fmt.Fprintf(&b, "  %s\n", repo.Repo)
+			for _, item := range repo.Items {
+				fmt.Fprintf(&b, "    - [%s] %s\n", lang.Label("highlight", item.Kind), item.Title)
+			}
+		}
+	}
+
+	// リポジトリ別（今週のコミット数の多い順、先週のみのリポジトリは後ろ）
+	if (len(current.RepoDetails) > 0 || len(previous.RepoDetails) > 0) && recipient.Show(SectionRepos) {
+		writeHeading(&b, lang.T("text.repos"))
+		writeTable(&b, compareHeader(lang, "text.repo"), compareCounts(repoCounts(current.RepoDetails), repoCounts(previous.RepoDetails)), 1)
+	}
+
+	// 主要言語
+	if languages := current.MainLanguagesBy(comparison.LanguageMetric); len(languages) > 0 && recipient.Show(SectionLanguages) {
+		writeHeading(&b, languageTitle(lang, comparison.LanguageMetric))
+		writeTable(&b, compareHeader(lang, "text.language"), compareCounts(languages, previous.MainLanguagesBy(comparison.LanguageMetric)), 1)
+	}
+
+	// ワークライフバランス
+	if recipient.Show(SectionWorkLife) {
+		writeWorkLifeText(&b, lang, comparison)
+	}
+
+	return b.String()
+}
+
+// ワークライフバランスと注意のテキスト版
+func writeWorkLifeText(b *strings.Builder, lang i18n.Language, comparison github.WeeklyComparison) {
+	workLife := comparison.CurrentWeek.WorkLife
+	writeHeading(b, lang.T("report.worklife"))
+	writeTable(b, nil, [][]string{
+		{lang.T("report.after_hours"), fmt.Sprint(workLife.AfterHoursCommits), fmt.Sprintf("%d%%", workLife.AfterHoursShare)},
+		{lang.T("report.weekend"), fmt.Sprint(workLife.WeekendCommits), fmt.Sprintf("%d%%", workLife.WeekendShare)},
+		{lang.T("report.late_night"), fmt.Sprint(workLife.LateNightCommits), ""},
+	}, 1)
+	if warning := comparison.WorkLifeWarning; warning != nil {
+		b.WriteString("  ※ " + workLifeWarning(lang, warning) + "\n")
+	}
+}
+
+// ワークライフバランスの注意の文言
+func workLifeWarning(lang i18n.Language, warning *github.WorkLifeWarning) string {
+	kind := "report.warning_late_night"
+	if warning.AfterHours {
+		kind = "report.warning_after_hours"
+	} else if warning.Weekend {
+		kind = "report.warning_weekend"
+	}
+	return lang.T("report.worklife_warning", warning.ConsecutiveWeeks, lang.T(kind))
+}
+
+// チームダイジェストのテキスト版を生成
+func RenderTeamText(report github.TeamReport, lang i18n.Language) string {
+	team := report.Team
+	var b strings.Builder
+
+	b.WriteString(textRule + "\n")
+	b.WriteString(lang.T("team.title") + "\n")
+	b.WriteString(textRule + "\n\n")
+
+	b.WriteString(lang.T("text.period", lang.FullDate(team.StartDate), lang.FullDate(team.EndDate)) + "\n")
+	b.WriteString(lang.T("text.total_commits", team.TotalCommits, team.PreviousCommits, formatChange(lang, team.CommitsDiff, team.CommitsChangeRate)) + "\n")
+	b.WriteString(lang.T("text.active_members", team.ActiveMembers, len(team.Members)) + "\n")
+
+	writeHeading(&b, lang.T("text.members"))
+	members := [][]string{}
+	for _, member := range team.Members {
+		members = append(members, []string{member.Username, fmt.Sprint(member.TotalCommits), fmt.Sprint(member.ActiveDays), formatDiff(member.CommitsDiff)})
+	}
+	writeTable(&b, []string{lang.T("text.member"), lang.T("text.commits"), lang.T("text.member_days"), lang.T("text.member_diff")}, members, 1)
+
+	if len(team.RepoDetails) > 0 {
+		writeHeading(&b, lang.T("text.repos"))
+		repos := [][]string{}
+		for _, repo := range team.RepoDetails {
+			repos = append(repos, []string{repo.Name, fmt.Sprint(repo.Count)})
+		}
+		writeTable(&b, []string{lang.T("text.repo"), lang.T("text.commits")}, repos, 1)
+	}
+
+	return b.String()
+}
+
+// 見出し（"■ 今週の目標"）
+func writeHeading(b *strings.Builder, title string) {
+	b.WriteString("\n■ " + title + "\n")
+}
+
+// 今週・先週を比較する表の見出し
+func compareHeader(lang i18n.Language, nameKey string) []string {
+	return []string{lang.T(nameKey), lang.T("text.this_week"), lang.T("text.last_week"), lang.T("text.diff")}
+}
+
+// 言語の指標ごとの見出し（未知の指標は変更ファイル数）
+func languageTitle(lang i18n.Language, metric string) string {
+	switch metric {
+	case github.LanguageMetricLines, github.LanguageMetricCommits:
+		return lang.T("languages." + metric)
+	default:
+		return lang.T("languages." + github.LanguageMetricFiles)
+	}
+}
+
+// 前週比の表示（"+12、40% 増加" など）
+func formatChange(lang i18n.Language, diff, rate int) string {
+	switch {
+	case diff > 0:
+		return lang.T("text.increase", diff, rate)
+	case diff < 0:
+		return lang.T("text.decrease", diff, -rate)
+	default:
+		return lang.T("text.no_change")
+	}
+}
+
+// 差分の表示（符号付き、0 は "0"）
+func formatDiff(diff int) string {
+	if diff == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%+d", diff)
+}
+
+// RepoDetails をリポジトリ名ごとのコミット数に変換
+func repoCounts(repos []github.RepoDetail) map[string]int {
+	counts := make(map[string]int)
+	for _, repo := range repos {
+		counts[repo.Name] = repo.Count
+	}
+	return counts
+}
+
+// 今週・先週の値を比較した行を生成（今週の多い順、同数は名前順）
+func compareCounts(current, previous map[string]int) [][]string {
+	names := slices.Collect(maps.Keys(current))
+	for name := range previous {
+		if _, exists := current[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(current[b], current[a]), cmp.Compare(previous[b], previous[a]), strings.Compare(a, b))
+	})
+
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, []string{name, fmt.Sprint(current[name]), fmt.Sprint(previous[name]), formatDiff(current[name] - previous[name])})
+	}
+	return rows
+}
+
+// 表を字下げして書き出す（numeric 列目より前は左寄せ、以降は右寄せ）
+// 全角文字は幅2として揃える
+func writeTable(b *strings.Builder, header []string, rows [][]string, numeric int) {
+	all := rows
+	if header != nil {
+		all = append([][]string{header}, rows...)
+	}
+
+	widths := []int{}
+	for _, row := range all {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+
+	writeRow := func(row []string) {
+		var line strings.Builder
+		line.WriteString("  ")
+		for i, cell := range row {
+			if i > 0 {
+				line.WriteString("  ")
+			}
+			padding := strings.Repeat(" ", widths[i]-displayWidth(cell))
+			if i >= numeric {
+				line.WriteString(padding + cell)
+			} else {
+				line.WriteString(cell + padding)
+			}
+		}
+		b.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+
+	if header != nil {
+		writeRow(header)
+		total := 0
+		for _, width := range widths {
+			total += width
+		}
+		b.WriteString("  " + strings.Repeat("-", total+2*(len(widths)-1)) + "\n")
+	}
+	for _, row := range rows {
+		writeRow(row)
+	}
+}
+
+// 表示幅（全角文字を2として数える）
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if r >= 0x1100 && utf8.RuneLen(r) >= 3 && !(r >= 0xFF61 && r <= 0xFF9F) {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return width
+}
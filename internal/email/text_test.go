@@ -0,0 +1,146 @@
+package email
+
+import (
+	"flag"
+	"github-weekly-log/internal/github"
+	"github-weekly-log/internal/i18n"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// go test ./internal/email -update でゴールデンファイルを更新する
+var update = flag.Bool("update", false, "ゴールデンファイルを更新する")
+
+// 生成結果をゴールデンファイルと比較
+func assertGolden(t *testing.T, name string, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s mismatch (-update で更新)\n--- got ---\n%s\n--- want ---\n%s", name, got, want)
+	}
+}
+
+// テスト用の週間データ
+func newTextTestComparison() github.WeeklyComparison {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	start := time.Date(2026, 2, 14, 0, 0, 0, 0, jst)
+	weekdays := []string{"日", "月", "火", "水", "木", "金", "土"}
+	counts := []int{0, 5, 12, 0, 8, 15, 2}
+
+	current := &github.WeeklyStats{
+		TotalCommits: 42,
+		ActiveDays:   5,
+		StartDate:    start,
+		EndDate:      start.AddDate(0, 0, 6),
+		RepoDetails: []github.RepoDetail{
+			{Name: "awesome-project", Count: 25},
+			{Name: "go-utils", Count: 12},
+			{Name: "dotfiles", Count: 5},
+		},
+		MainLanguages: map[string]int{"Go": 120, "TypeScript": 85, "Python": 30},
+		WorkLife:      github.WorkLifeBalance{AfterHoursCommits: 10, AfterHoursShare: 24, WeekendCommits: 2, WeekendShare: 5, LateNightCommits: 3},
+		Goals: []github.GoalResult{
+			{Metric: github.GoalActiveDays, Label: "活動日数", Target: 5, Actual: 5, Percent: 100, Achieved: true},
+			{Metric: github.GoalCommits, Label: "コミット数", Target: 50, Actual: 42, Percent: 84},
+		},
+		Shipped: []github.ShippedRepo{
+			{Repo: "awesome-project", Items: []github.ShippedItem{{Type: "feat", Scope: "auth", Subject: "ログインを追加"}, {Type: "fix", Subject: "crash on start"}}},
+		},
+		Highlights: []github.RepoHighlights{
+			{Repo: "awesome-project", Items: []github.Highlight{{Kind: github.HighlightRelease, Title: "v1.2.0"}, {Kind: github.HighlightPullRequest, Title: "Add login"}}},
+		},
+	}
+	for i, count := range counts {
+		date := start.AddDate(0, 0, i)
+		current.DailyCommits = append(current.DailyCommits, github.DailyCommit{Date: date, DateStr: date.Format("1/2"), Weekday: weekdays[date.Weekday()], Count: count})
+	}
+
+	previous := &github.WeeklyStats{
+		TotalCommits:  30,
+		StartDate:     start.AddDate(0, 0, -7),
+		EndDate:       start.AddDate(0, 0, -1),
+		RepoDetails:   []github.RepoDetail{{Name: "awesome-project", Count: 18}, {Name: "legacy-app", Count: 12}},
+		MainLanguages: map[string]int{"Go": 90, "Rust": 10},
+	}
+
+	return github.WeeklyComparison{
+		CurrentWeek:       current,
+		PreviousWeek:      previous,
+		CommitsDiff:       12,
+		CommitsChangeRate: 40,
+		LanguageMetric:    github.LanguageMetricFiles,
+		Streaks:           &github.Streaks{CurrentDays: 3, LongestDays: 9, CurrentWeeks: 6, LongestWeeks: 10},
+		WorkLifeWarning:   &github.WorkLifeWarning{ConsecutiveWeeks: 3, Weekend: true},
+		GoalSummaries: []github.GoalSummary{
+			{Metric: github.GoalActiveDays, Label: "活動日数", Weeks: 4, Achieved: 3, HitRate: 75},
+			{Metric: github.GoalCommits, Label: "コミット数", Weeks: 4, Achieved: 1, HitRate: 25},
+		},
+	}
+}
+
+// テスト: 週間レポートのテキスト版
+func TestRenderText(t *testing.T) {
+	assertGolden(t, "weekly.golden.txt", RenderText(newTextTestComparison()))
+}
+
+// テスト: 英語の送信先には英語のテキスト版を生成する
+func TestRenderTextEnglish(t *testing.T) {
+	recipient := Recipient{Name: "Alice", Language: i18n.English}
+	assertGolden(t, "weekly.en.golden.txt", RenderRecipientText(newTextTestComparison(), recipient))
+}
+
+// テスト: 先週のデータがない場合も生成できる
+func TestRenderTextWithoutPreviousWeek(t *testing.T) {
+	comparison := newTextTestComparison()
+	comparison.PreviousWeek = nil
+	text := RenderText(comparison)
+	if !strings.Contains(text, "総コミット数: 42（先週 0") || !strings.Contains(text, "awesome-project") {
+		t.Errorf("unexpected text:\n%s", text)
+	}
+}
+
+// テスト: チームダイジェストのテキスト版
+func TestRenderTeamText(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	start := time.Date(2026, 2, 14, 0, 0, 0, 0, jst)
+	report := github.TeamReport{
+		Team: &github.TeamStats{
+			TotalCommits:      57,
+			PreviousCommits:   60,
+			CommitsDiff:       -3,
+			CommitsChangeRate: -5,
+			ActiveMembers:     2,
+			Members: []github.MemberSummary{
+				{Username: "alice", TotalCommits: 42, ActiveDays: 5, CommitsDiff: 12},
+				{Username: "bob", TotalCommits: 15, ActiveDays: 3, CommitsDiff: -15},
+				{Username: "carol", TotalCommits: 0, ActiveDays: 0, CommitsDiff: 0},
+			},
+			RepoDetails: []github.RepoDetail{{Name: "team/api", Count: 30}, {Name: "team/web", Count: 27}},
+			StartDate:   start,
+			EndDate:     start.AddDate(0, 0, 6),
+		},
+	}
+	assertGolden(t, "team.golden.txt", RenderTeamText(report, i18n.Japanese))
+}
+
+// テスト: 全角文字の表示幅
+func TestDisplayWidth(t *testing.T) {
+	tests := map[string]int{"Go": 2, "活動日数": 8, "ｱｲｳ": 3, "go-utils（旧）": 14}
+	for s, expected := range tests {
+		if got := displayWidth(s); got != expected {
+			t.Errorf("displayWidth(%q): expected %d, got %d", s, expected, got)
+		}
+	}
+}
@@ -3,9 +3,9 @@ package github
 import (
 	"context"
 	"fmt"
+	"github-weekly-log/internal/i18n"
 	"maps"
 	"math"
-	"path/filepath"
 	"slices"
 	"strings"
 	"time"
@@ -14,81 +14,203 @@ import (
 )
 
 type Client struct {
-	ghClient *github.Client
+	ghClient  *github.Client
+	opts      Options
+	authLogin string // 認証ユーザーのログイン名（キャッシュ）
+
+	sharedRepos       []*github.Repository // トークンで参照できる組織・コラボレーターのリポジトリ（キャッシュ）
+	sharedReposLoaded bool
+}
+
+// クライアントの設定
+type Options struct {
+	LanguageFilter LanguageFilter // 言語集計から除外するファイルの種類
+	LanguageMetric string         // メールに表示する言語集計の指標（files / lines / commits）
+	WorkSchedule   *WorkSchedule  // 勤務時間・勤務日の設定（nil の場合は既定値）
+	RepoFilter     *RepoFilter    // 集計対象のリポジトリを決めるルール（nil の場合は既定値）
+	CommitFilter   *CommitFilter  // 集計から除外するコミットのルール（nil の場合は除外しない）
+	Goals          []Goal         // 週ごとの目標（nil の場合は判定しない）
+	GoalWeeks      int            // 目標の達成率を集計する週数（0 の場合は既定値）
 }
 
 // 日次コミットデータ
 type DailyCommit struct {
 	Date    time.Time // 日付
 	DateStr string    // "1/2" 形式の日付文字列
-	Weekday string    // "月", "火" など
+	Weekday string    // "月", "火" など（既定の言語、表示時は Date から言語に合わせて生成する）
 	Count   int       // コミット数
 }
 
 // リポジトリの詳細情報
 type RepoDetail struct {
-	Name       string  // リポジトリ名
-	Count      int     // コミット数
-	BarPercent float64 // バー幅（0-100%）
+	Name            string         // リポジトリ名
+	Count           int            // コミット数
+	BarPercent      float64        // バー幅（0-100%）
+	Languages       map[string]int // このリポジトリでの言語ごとの変更ファイル数
+	Additions       int            // 追加行数
+	Deletions       int            // 削除行数
+	Private         bool           // プライベートリポジトリか
+	PrimaryLanguage string         // GitHub が判定したリポジトリの主要言語
+	URL             string         // リポジトリのURL
+	PushedAt        time.Time      // 最終プッシュ日時
 }
 
 // 週間コミットデータ構造体
 type WeeklyStats struct {
-	TotalCommits    int            `json:"totalCommits"`    // 累計コミット数
-	DailyCommits    []DailyCommit  `json:"dailyCommits"`    // 7日分の日次データ（順序保証）
-	HourlyActivity  [24]int        `json:"hourlyActivity"`  // 時間帯ごとのコミット数
-	RepoDetails     []RepoDetail   `json:"repoDetails"`     // リポジトリの詳細情報（バー幅計算済み）
-	LanguageCommits map[string]int `json:"languageCommits"` // 言語ごとのコミット数
-	MainLanguages   map[string]int `json:"mainLanguages"`   // 主要言語ごとのコミット数
-	StartDate       time.Time      `json:"startDate"`       // 週間開始日
-	EndDate         time.Time      `json:"endDate"`         // 週間終了日
-	ActiveDays      int            `json:"activeDays"`      // コミットがあった日数（DailyCommits から計算）
+	Username              string                    `json:"username"`              // 集計対象のユーザー名
+	TotalCommits          int                       `json:"totalCommits"`          // 累計コミット数
+	DailyCommits          []DailyCommit             `json:"dailyCommits"`          // 7日分の日次データ（順序保証）
+	HourlyActivity        [24]int                   `json:"hourlyActivity"`        // 時間帯ごとのコミット数
+	WeekdayHourlyActivity Heatmap                   `json:"weekdayHourlyActivity"` // 曜日×時間帯ごとのコミット数
+	RepoDetails           []RepoDetail              `json:"repoDetails"`           // リポジトリの詳細情報（バー幅計算済み）
+	LanguageCommits       map[string]int            `json:"languageCommits"`       // 言語ごとのコミット数
+	MainLanguages         map[string]int            `json:"mainLanguages"`         // 主要言語ごとのコミット数
+	LanguageLines         map[string]int            `json:"languageLines"`         // 言語ごとの変更行数（追加+削除）
+	LanguageTouches       map[string]int            `json:"languageTouches"`       // 言語ごとの変更コミット数（1コミットにつき1回）
+	StartDate             time.Time                 `json:"startDate"`             // 週間開始日
+	EndDate               time.Time                 `json:"endDate"`               // 週間終了日
+	ActiveDays            int                       `json:"activeDays"`            // コミットがあった日数（DailyCommits から計算）
+	WorkLife              WorkLifeBalance           `json:"workLife"`              // 勤務時間外・休日・深夜帯のコミット
+	CommitTypes           map[string]int            `json:"commitTypes"`           // Conventional Commits の種類ごとのコミット数
+	CommitScopes          map[string]int            `json:"commitScopes"`          // スコープごとのコミット数
+	RepoCommitTypes       map[string]map[string]int `json:"repoCommitTypes"`       // リポジトリ・種類ごとのコミット数
+	Shipped               []ShippedRepo             `json:"shipped"`               // feat / fix のコミット（リポジトリごと）
+	Highlights            []RepoHighlights          `json:"highlights"`            // リポジトリごとのリリース・PR・コミットのハイライト
+	Hotspots              []RepoHotspots            `json:"hotspots"`              // リポジトリごとによく変更されたファイル・ディレクトリ
+	ExcludedCommits       ExcludedCommits           `json:"excludedCommits"`       // ルールで除外したコミット・ファイルの数（TotalCommits に含まない）
+	MergedPullRequests    int                       `json:"mergedPullRequests"`    // マージされた自分のプルリクエスト数（コミットがあったリポジトリのみ）
+	Goals                 []GoalResult              `json:"goals"`                 // 目標の達成状況
+
+	privateRepos map[string]bool // プライベートリポジトリの名前（プライバシー設定用、出力しない）
 }
 
 // 前週比較データ構造体
 type WeeklyComparison struct {
-	CurrentWeek       *WeeklyStats `json:"currentWeek"`       // 今週のデータ
-	PreviousWeek      *WeeklyStats `json:"previousWeek"`      // 先週のデータ
-	CommitsDiff       int          `json:"commitsDiff"`       // コミット数の差分
-	CommitsChangeRate int          `json:"commitsChangeRate"` // コミット数の変化率（%）
+	CurrentWeek       *WeeklyStats     `json:"currentWeek"`       // 今週のデータ
+	PreviousWeek      *WeeklyStats     `json:"previousWeek"`      // 先週のデータ
+	CommitsDiff       int              `json:"commitsDiff"`       // コミット数の差分
+	CommitsChangeRate int              `json:"commitsChangeRate"` // コミット数の変化率（%）
+	LanguageMetric    string           `json:"languageMetric"`    // メールに表示する言語集計の指標（files / lines / commits）
+	Streaks           *Streaks         `json:"streaks"`           // 継続記録（履歴から計算）
+	Trends            *Trends          `json:"trends"`            // 4週・12週平均や昨年同週との比較（履歴から計算）
+	LongTermHeatmap   *Heatmap         `json:"longTermHeatmap"`   // 複数週を合算した曜日×時間帯のコミット数
+	LongTermWeeks     int              `json:"longTermWeeks"`     // LongTermHeatmap に含まれる週数
+	WorkLifeWarning   *WorkLifeWarning `json:"workLifeWarning"`   // 閾値を連続で超えた場合の注意（超えていない場合は nil）
+	GoalSummaries     []GoalSummary    `json:"goalSummaries"`     // 直近の週での目標の達成率
 }
 
 // クライアントの生成
 func NewClient(token string) *Client {
+	return NewClientWithOptions(token, Options{})
+}
+
+// 設定を指定してクライアントを生成
+func NewClientWithOptions(token string, opts Options) *Client {
 	return &Client{
 		ghClient: github.NewClient(nil).WithAuthToken(token),
+		opts:     opts,
+	}
+}
+
+// 勤務時間・勤務日の設定
+func (c *Client) WorkSchedule() WorkSchedule {
+	if c.opts.WorkSchedule == nil {
+		return DefaultWorkSchedule()
 	}
+	return *c.opts.WorkSchedule
+}
+
+// クライアントの勤務スケジュールでワークライフバランスの注意を判定
+func (c *Client) EvaluateWorkLifeWarning(history []*WeeklyStats, comparison *WeeklyComparison) *WorkLifeWarning {
+	return EvaluateWorkLifeWarning(history, comparison, c.WorkSchedule())
+}
+
+// 目標の達成率を集計する週数
+func (c *Client) GoalWeeks() int {
+	if c.opts.GoalWeeks <= 0 {
+		return GOAL_SUMMARY_WEEKS
+	}
+	return c.opts.GoalWeeks
+}
+
+// クライアントの設定で目標の達成率を計算
+func (c *Client) SummarizeGoals(history []*WeeklyStats, comparison *WeeklyComparison) []GoalSummary {
+	return SummarizeGoals(history, comparison, c.GoalWeeks())
+}
+
+// 集計対象のリポジトリを決めるルール
+func (c *Client) RepoFilter() RepoFilter {
+	if c.opts.RepoFilter == nil {
+		return DefaultRepoFilter()
+	}
+	return *c.opts.RepoFilter
+}
+
+// リポジトリ一覧と、それぞれが集計対象になるかの判定結果を取得
+func (c *Client) ListRepositoryDecisions(ctx context.Context, username string) ([]RepoDecision, error) {
+	repos, err := c.listRepositories(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := c.RepoFilter()
+	decisions := make([]RepoDecision, 0, len(repos))
+	for _, repo := range repos {
+		decisions = append(decisions, filter.Evaluate(repo))
+	}
+	return decisions, nil
+}
+
+// 集計から除外するコミットのルール
+func (c *Client) CommitFilter() CommitFilter {
+	if c.opts.CommitFilter == nil {
+		return CommitFilter{}
+	}
+	return *c.opts.CommitFilter
 }
 
 // データ取得ロジック
 func (c *Client) FetchWeeklyCommits(ctx context.Context, username string) (*WeeklyStats, error) {
 	// 週間の開始日と終了日を取得
 	startDate, endDate := getTargetRange()
-	return c.fetchWeeklyCommitsInRange(ctx, username, startDate, endDate)
+	return c.fetchWeeklyCommitsInRange(ctx, username, startDate, endDate, fetchScope{highlights: true})
 }
 
 // 前週比を含むデータ取得
 func (c *Client) FetchWeeklyCommitsWithComparison(ctx context.Context, username string) (*WeeklyComparison, error) {
+	startDate, _ := getTargetRange()
+	return c.FetchWeeklyCommitsWithComparisonFrom(ctx, username, startDate)
+}
+
+// startDate（土曜日0時）から始まる週の前週比を含むデータ取得（未送信の週の再送用）
+func (c *Client) FetchWeeklyCommitsWithComparisonFrom(ctx context.Context, username string, startDate time.Time) (*WeeklyComparison, error) {
+	return c.fetchWeeklyComparison(ctx, username, startDate, false)
+}
+
+// 前週比を含むデータ取得（内部用）
+// shared が true の場合は、組織・コラボレーターのリポジトリも対象にする（チームモード）
+func (c *Client) fetchWeeklyComparison(ctx context.Context, username string, startDate time.Time, shared bool) (*WeeklyComparison, error) {
 	// 今週のデータを取得
-	currentStart, currentEnd := getTargetRange()
-	currentWeek, err := c.fetchWeeklyCommitsInRange(ctx, username, currentStart, currentEnd)
+	currentStart, currentEnd := startDate, startDate.AddDate(0, 0, 6)
+	currentWeek, err := c.fetchWeeklyCommitsInRange(ctx, username, currentStart, currentEnd, fetchScope{highlights: true, shared: shared})
 	if err != nil {
 		return nil, fmt.Errorf("error fetching current week data: %v", err)
 	}
 
-	// 先週のデータを取得
+	// 先週のデータを取得（ハイライトは今週分しか使わないため、PR・リリースは取得しない）
 	previousStart := currentStart.AddDate(0, 0, -7)
 	previousEnd := currentEnd.AddDate(0, 0, -7)
-	previousWeek, err := c.fetchWeeklyCommitsInRange(ctx, username, previousStart, previousEnd)
+	previousWeek, err := c.fetchWeeklyCommitsInRange(ctx, username, previousStart, previousEnd, fetchScope{shared: shared})
 	if err != nil {
 		return nil, fmt.Errorf("error fetching previous week data: %v", err)
 	}
 
 	// 比較データを計算
 	comparison := &WeeklyComparison{
-		CurrentWeek:  currentWeek,
-		PreviousWeek: previousWeek,
-		CommitsDiff:  currentWeek.TotalCommits - previousWeek.TotalCommits,
+		CurrentWeek:    currentWeek,
+		PreviousWeek:   previousWeek,
+		CommitsDiff:    currentWeek.TotalCommits - previousWeek.TotalCommits,
+		LanguageMetric: normalizeLanguageMetric(c.opts.LanguageMetric),
 	}
 
 	// 変化率を計算
@@ -99,14 +221,33 @@ func (c *Client) FetchWeeklyCommitsWithComparison(ctx context.Context, username
 		comparison.CommitsChangeRate = 100 // 0から増加した場合は100%とする
 	}
 
+	// 継続記録（保存済みの履歴がない場合は今週・先週のデータのみで計算）
+	comparison.Streaks = CalculateStreaks(nil, comparison)
+	comparison.LongTermHeatmap, comparison.LongTermWeeks = AggregateHeatmap([]*WeeklyStats{previousWeek}, currentWeek, HEATMAP_WEEKS)
+	comparison.WorkLifeWarning = c.EvaluateWorkLifeWarning(nil, comparison)
+	comparison.GoalSummaries = c.SummarizeGoals(nil, comparison)
+
 	return comparison, nil
 }
 
+// 指定期間に取得するデータの範囲
+type fetchScope struct {
+	highlights bool // ハイライト用のPR・リリースを取得する（false の場合は merged_prs の目標がある場合のみPR数を数える）
+	shared     bool // 組織・コラボレーターのリポジトリも対象にする（チームモード）
+}
+
 // 指定期間のコミットデータを取得（内部用）
-func (c *Client) fetchWeeklyCommitsInRange(ctx context.Context, username string, startDate, endDate time.Time) (*WeeklyStats, error) {
+func (c *Client) fetchWeeklyCommitsInRange(ctx context.Context, username string, startDate, endDate time.Time, scope fetchScope) (*WeeklyStats, error) {
 	stats := &WeeklyStats{
+		Username:        username,
 		LanguageCommits: make(map[string]int),
 		MainLanguages:   make(map[string]int),
+		LanguageLines:   make(map[string]int),
+		LanguageTouches: make(map[string]int),
+		CommitTypes:     make(map[string]int),
+		CommitScopes:    make(map[string]int),
+		RepoCommitTypes: make(map[string]map[string]int),
+		privateRepos:    make(map[string]bool),
 		StartDate:       startDate,
 		EndDate:         endDate,
 	}
@@ -114,37 +255,42 @@ func (c *Client) fetchWeeklyCommitsInRange(ctx context.Context, username string,
 	// 内部用：日付ごと、リポジトリごとのコミット数を一時保持
 	commitDays := make(map[string]int)
 	repoCommits := make(map[string]int)
+	repoInfo := make(map[string]*RepoDetail)
+	highlights := make(map[string]*highlightSources)
+	hotspots := make(map[string]*hotspotCounter)
 
 	// ユーザーのリポジトリ一覧を取得
-	opts := &github.RepositoryListByAuthenticatedUserOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
-		Sort:        "pushed",
-		Direction:   "desc",
-		Visibility:  "all",
-		Affiliation: "owner", // 所有しているリポジトリのみ
+	allRepos, err := c.listRepositories(ctx, username)
+	if err != nil {
+		return nil, err
 	}
-
-	var allRepos []*github.Repository
-
-	// 全リポジトリを取得
-	for {
-		repos, resp, err := c.ghClient.Repositories.ListByAuthenticatedUser(ctx, opts)
+	if scope.shared {
+		shared, err := c.listSharedRepositories(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error fetching repositories: %v", err)
-		}
-		allRepos = append(allRepos, repos...)
-		if resp.NextPage == 0 {
-			break
+			return nil, err
 		}
-		opts.Page = resp.NextPage
+		allRepos = mergeRepositories(allRepos, shared)
 	}
 
 	// 各リポジトリのコミットを取得
+	filter := c.RepoFilter()
+	commitFilter := c.CommitFilter()
+	// 先週の merged_prs の目標の達成状況は達成率の計算に使うため、ハイライトを取得しない場合もPR数は数える
+	countPulls := slices.ContainsFunc(c.opts.Goals, func(goal Goal) bool { return goal.Metric == GoalMergedPRs })
 	for _, repo := range allRepos {
-		repoName := repo.GetName()
+		// 他の所有者のリポジトリは同名のリポジトリと区別するため "owner/repo" 形式で集計する
+		owner := repo.GetOwner().GetLogin()
+		if owner == "" {
+			owner = username
+		}
+		repoName := repoKey(owner, repo.GetName(), username)
+
+		if repo.GetPrivate() {
+			stats.privateRepos[repoName] = true
+		}
 
 		// 除外リポジトリをスキップ
-		if isRepositoryExcluded(repoName) {
+		if !filter.Evaluate(repo).Included {
 			continue
 		}
 
@@ -157,16 +303,9 @@ func (c *Client) fetchWeeklyCommitsInRange(ctx context.Context, username string,
 
 		// 検索用：endDate は金曜日なので、検索範囲は土曜日0時まで（金曜日24時）
 		searchUntil := endDate.AddDate(0, 0, 1)
-		commitOpts := &github.CommitsListOptions{
-			Author:      username,
-			Since:       startDate,
-			Until:       searchUntil,
-			ListOptions: github.ListOptions{PerPage: 100},
-		}
 
-		// 全コミットを取得
-		commits, _, err := c.ghClient.Repositories.ListCommits(
-			ctx, username, repo.GetName(), commitOpts)
+		// 本人のコミットを取得（bot のコミットは本人に紐づくものだけを除外の対象にする）
+		commits, err := c.listCommits(ctx, owner, repo.GetName(), username, startDate, searchUntil)
 		if err != nil {
 			fmt.Printf("Error fetching commits for %s: %v\n", repo.GetName(), err)
 			continue
@@ -184,32 +323,83 @@ func (c *Client) fetchWeeklyCommitsInRange(ctx context.Context, username string,
 				continue
 			}
 
+			// bot・メッセージのルールで除外
+			if reason := commitFilter.excludeReason(commit); reason != "" {
+				stats.ExcludedCommits.add(reason)
+				continue
+			}
+
+			// ファイル情報を取得（取得できない場合もコミットは集計する）
+			commitDetail, _, err := c.ghClient.Repositories.GetCommit(
+				ctx, owner, repo.GetName(), commit.GetSHA(), nil)
+			if err != nil {
+				fmt.Printf("Error fetching commit details for %s: %v\n", repoName, err)
+			}
+
+			// 除外パスのファイルのみを変更したコミットを除外
+			if commitDetail != nil && commitFilter.onlyExcludedPaths(commitDetail.Files) {
+				stats.ExcludedCommits.add(CommitExcludedPaths)
+				continue
+			}
+
 			stats.TotalCommits++
 
 			jst := commitDate.In(time.FixedZone("Asia/Tokyo", 9*60*60))
 			dateStr := jst.Format("2006-01-02")
 			commitDays[dateStr]++
 			stats.HourlyActivity[jst.Hour()]++
+			stats.WeekdayHourlyActivity[jst.Weekday()][jst.Hour()]++
 
-			repoName := repo.GetName()
 			repoCommits[repoName]++
+			if repoInfo[repoName] == nil {
+				repoInfo[repoName] = newRepoDetail(repo, repoName)
+			}
+
+			// コミットメッセージを種類・スコープごとに集計
+			accumulateCommitMessage(stats, repoName, commit.Commit.GetMessage())
+
+			// マージコミット以外の件名をハイライト候補にする
+			if !isMergeCommit(commit) {
+				if highlights[repoName] == nil {
+					highlights[repoName] = &highlightSources{}
+				}
+				highlights[repoName].commits = append(highlights[repoName].commits, Highlight{
+					Kind:  HighlightCommit,
+					Title: commitSubject(commit.Commit.GetMessage()),
+				})
+			}
 
 			// コミットの言語集計
-			// ファイル情報を取得
-			commitDetail, _, err := c.ghClient.Repositories.GetCommit(
-				ctx, username, repoName, commit.GetSHA(), nil)
-			if err != nil {
-				fmt.Printf("Error fetching commit details for %s: %v\n", repoName, err)
+			if commitDetail == nil {
 				continue
 			}
+			files, ignored := commitFilter.languageFiles(commitDetail.Files)
+			stats.ExcludedCommits.IgnoredFiles += ignored
 			// 変更されたファイルごとに言語を集計
-			for _, file := range commitDetail.Files {
-				filename := file.GetFilename()
-				language := getLanguageFromFilename(filename)
-				if language != "" {
-					stats.LanguageCommits[language]++
-				}
+			accumulateLanguages(stats, files, c.opts.LanguageFilter)
+			accumulateRepoDetail(repoInfo[repoName], files, c.opts.LanguageFilter)
+			// 変更されたファイル・ディレクトリをホットスポットとして集計
+			accumulateHotspots(hotspots, repoName, files, c.opts.LanguageFilter)
+		}
+
+		// コミットがあったリポジトリのみ、マージされたPRとリリースをハイライト候補にする
+		source, exists := highlights[repoName]
+		if !exists || (!scope.highlights && !countPulls) {
+			continue
+		}
+		pulls, err := c.fetchMergedPullRequests(ctx, owner, repo.GetName(), username, startDate, searchUntil)
+		if err != nil {
+			fmt.Printf("Error fetching pull requests for %s: %v\n", repoName, err)
+		}
+		stats.MergedPullRequests += len(pulls)
+		if scope.highlights {
+			source.pulls = pulls
+
+			releases, err := c.fetchReleases(ctx, owner, repo.GetName(), startDate, searchUntil)
+			if err != nil {
+				fmt.Printf("Error fetching releases for %s: %v\n", repoName, err)
 			}
+			source.releases = releases
 		}
 	}
 
@@ -229,11 +419,23 @@ func (c *Client) fetchWeeklyCommitsInRange(ctx context.Context, username string,
 		}
 	}
 
+	// 「今週の成果」を並べ替え
+	sortShipped(stats.Shipped)
+
 	// 主要言語のみフィルタリング
 	stats.MainLanguages = filterMainLanguages(stats.LanguageCommits)
 
 	// リポジトリの詳細情報を生成（バー幅計算済み）
 	stats.RepoDetails = generateRepoDetails(repoCommits)
+	enrichRepoDetails(stats.RepoDetails, repoInfo)
+
+	// リポジトリごとのハイライト・ホットスポット（コミット数の多いリポジトリから）
+	repoOrder := make([]string, 0, len(stats.RepoDetails))
+	for _, repo := range stats.RepoDetails {
+		repoOrder = append(repoOrder, repo.Name)
+	}
+	stats.Highlights = buildHighlights(repoOrder, highlights, HIGHLIGHTS_PER_REPO)
+	stats.Hotspots = buildHotspots(repoOrder, hotspots, HOTSPOT_TOP_ITEMS)
 
 	// 7日分のDailyCommitsを生成（コントリビュートグラフ用）
 	stats.DailyCommits = generateDailyCommits(startDate, commitDays)
@@ -245,9 +447,158 @@ func (c *Client) fetchWeeklyCommitsInRange(ctx context.Context, username string,
 		}
 	}
 
+	// 勤務時間外・休日・深夜帯のコミットを集計
+	stats.WorkLife = CalculateWorkLife(&stats.WeekdayHourlyActivity, c.WorkSchedule())
+
+	// 目標の達成状況を判定
+	stats.Goals = EvaluateGoals(stats, c.opts.Goals)
+
 	return stats, nil
 }
 
+// 期間内の author のコミットを全ページ取得
+func (c *Client) listCommits(ctx context.Context, owner, repo, author string, since, until time.Time) ([]*github.RepositoryCommit, error) {
+	opts := &github.CommitsListOptions{
+		Author:      author,
+		Since:       since,
+		Until:       until,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var commits []*github.RepositoryCommit
+	for {
+		page, resp, err := c.ghClient.Repositories.ListCommits(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, page...)
+		if resp.NextPage == 0 {
+			return commits, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ユーザーが所有するリポジトリ一覧を取得
+// 認証ユーザー本人の場合はプライベートリポジトリも含め、それ以外は公開リポジトリのみ取得する
+func (c *Client) listRepositories(ctx context.Context, username string) ([]*github.Repository, error) {
+	login, err := c.authenticatedLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var allRepos []*github.Repository
+
+	if strings.EqualFold(login, username) {
+		opts := &github.RepositoryListByAuthenticatedUserOptions{
+			ListOptions: github.ListOptions{PerPage: 100},
+			Sort:        "pushed",
+			Direction:   "desc",
+			Visibility:  "all",
+			Affiliation: "owner", // 所有しているリポジトリのみ
+		}
+
+		// 全リポジトリを取得
+		for {
+			repos, resp, err := c.ghClient.Repositories.ListByAuthenticatedUser(ctx, opts)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching repositories: %v", err)
+			}
+			allRepos = append(allRepos, repos...)
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+		return allRepos, nil
+	}
+
+	opts := &github.RepositoryListByUserOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+		Type:        "owner",
+		Sort:        "pushed",
+		Direction:   "desc",
+	}
+	for {
+		repos, resp, err := c.ghClient.Repositories.ListByUser(ctx, username, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching repositories for %s: %v", username, err)
+		}
+		allRepos = append(allRepos, repos...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return allRepos, nil
+}
+
+// トークンで参照できる組織・コラボレーターのリポジトリ一覧を取得（初回のみAPIを呼び出す）
+// チームのメンバーが共同で作業するリポジトリを、メンバーごとにコミットの作者で絞り込んで集計するために使う
+func (c *Client) listSharedRepositories(ctx context.Context) ([]*github.Repository, error) {
+	if c.sharedReposLoaded {
+		return c.sharedRepos, nil
+	}
+	opts := &github.RepositoryListByAuthenticatedUserOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+		Sort:        "pushed",
+		Direction:   "desc",
+		Visibility:  "all",
+		Affiliation: "collaborator,organization_member",
+	}
+	var repos []*github.Repository
+	for {
+		page, resp, err := c.ghClient.Repositories.ListByAuthenticatedUser(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching shared repositories: %v", err)
+		}
+		repos = append(repos, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	c.sharedRepos, c.sharedReposLoaded = repos, true
+	return repos, nil
+}
+
+// 重複を除いてリポジトリ一覧をつなげる（"owner/repo" で判定）
+func mergeRepositories(repos, others []*github.Repository) []*github.Repository {
+	seen := make(map[string]bool, len(repos))
+	merged := slices.Clone(repos)
+	for _, repo := range repos {
+		seen[strings.ToLower(repo.GetFullName())] = true
+	}
+	for _, repo := range others {
+		if name := strings.ToLower(repo.GetFullName()); !seen[name] {
+			seen[name] = true
+			merged = append(merged, repo)
+		}
+	}
+	return merged
+}
+
+// 集計に使うリポジトリ名（本人のリポジトリは名前のみ、それ以外は "owner/repo"）
+func repoKey(owner, name, username string) string {
+	if strings.EqualFold(owner, username) {
+		return name
+	}
+	return owner + "/" + name
+}
+
+// 認証ユーザーのログイン名を取得（初回のみAPIを呼び出す）
+func (c *Client) authenticatedLogin(ctx context.Context) (string, error) {
+	if c.authLogin != "" {
+		return c.authLogin, nil
+	}
+	user, _, err := c.ghClient.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("error fetching authenticated user: %v", err)
+	}
+	c.authLogin = user.GetLogin()
+	return c.authLogin, nil
+}
+
 // 週間の開始日と終了日を取得
 // テスト用：特定時刻でのターゲット範囲を計算
 func getTargetRangeAt(at time.Time) (time.Time, time.Time) {
@@ -276,21 +627,86 @@ func getTargetRange() (time.Time, time.Time) {
 	return getTargetRangeAt(time.Now())
 }
 
-func getLanguageFromFilename(filename string) string {
-	ext := strings.ToLower(filepath.Ext(filename))
+// 1コミット分の変更ファイルから言語ごとのファイル数・変更行数・コミット数を集計
+func accumulateLanguages(stats *WeeklyStats, files []*github.CommitFile, filter LanguageFilter) {
+	touched := make(map[string]bool)
+	for _, file := range files {
+		class := classifyFile(file.GetFilename())
+		if filter.Excludes(class) {
+			continue
+		}
+		stats.LanguageCommits[class.Language]++
+		stats.LanguageLines[class.Language] += file.GetAdditions() + file.GetDeletions()
+		touched[class.Language] = true
+	}
+	for lang := range touched {
+		stats.LanguageTouches[lang]++
+	}
+}
+
+// 未知の指標はファイル数として扱う
+func normalizeLanguageMetric(metric string) string {
+	switch metric {
+	case LanguageMetricLines, LanguageMetricCommits:
+		return metric
+	default:
+		return LanguageMetricFiles
+	}
+}
+
+// 指定した指標で主要言語の集計を返す（テンプレート用）
+func (s *WeeklyStats) MainLanguagesBy(metric string) map[string]int {
+	switch metric {
+	case LanguageMetricLines:
+		return filterMainLanguages(s.LanguageLines)
+	case LanguageMetricCommits:
+		return filterMainLanguages(s.LanguageTouches)
+	default:
+		return s.MainLanguages
+	}
+}
 
-	// 拡張子を確認する
-	if lang, exists := LANGUAGE_MAP[ext]; exists {
-		return lang
+// リポジトリ一覧の情報から RepoDetail を生成
+func newRepoDetail(repo *github.Repository, name string) *RepoDetail {
+	return &RepoDetail{
+		Name:            name,
+		Languages:       make(map[string]int),
+		Private:         repo.GetPrivate(),
+		PrimaryLanguage: repo.GetLanguage(),
+		URL:             repo.GetHTMLURL(),
+		PushedAt:        repo.GetPushedAt().Time,
 	}
+}
 
-	baseName := filepath.Base(filename)
-	// 特殊なファイル名を確認する
-	if lang, exists := SPECIAL_LANGUAGE_MAP[baseName]; exists {
-		return lang
+// 1コミット分の変更行数と言語をリポジトリごとに集計
+func accumulateRepoDetail(detail *RepoDetail, files []*github.CommitFile, filter LanguageFilter) {
+	for _, file := range files {
+		class := classifyFile(file.GetFilename())
+		if filter.Excludes(class) {
+			continue
+		}
+		// ロックファイルなど除外したファイルの変更行数は含めない
+		detail.Additions += file.GetAdditions()
+		detail.Deletions += file.GetDeletions()
+		detail.Languages[class.Language]++
 	}
+}
 
-	return "Other"
+// コミット数・バー幅以外の情報を RepoDetail に反映
+func enrichRepoDetails(details []RepoDetail, info map[string]*RepoDetail) {
+	for i := range details {
+		source, exists := info[details[i].Name]
+		if !exists {
+			continue
+		}
+		details[i].Languages = source.Languages
+		details[i].Additions = source.Additions
+		details[i].Deletions = source.Deletions
+		details[i].Private = source.Private
+		details[i].PrimaryLanguage = source.PrimaryLanguage
+		details[i].URL = source.URL
+		details[i].PushedAt = source.PushedAt
+	}
 }
 
 // 主要言語をフィルタリング
@@ -340,7 +756,6 @@ func generateRepoDetails(repoCommits map[string]int) []RepoDetail {
 
 // 7日分のDailyCommitsを生成（コントリビュートグラフ用）
 func generateDailyCommits(startDate time.Time, commitDays map[string]int) []DailyCommit {
-	weekdays := []string{"日", "月", "火", "水", "木", "金", "土"}
 	dailyCommits := make([]DailyCommit, 0, 7)
 
 	// 7日分のデータを生成
@@ -352,20 +767,10 @@ func generateDailyCommits(startDate time.Time, commitDays map[string]int) []Dail
 		dailyCommits = append(dailyCommits, DailyCommit{
 			Date:    date,
 			DateStr: date.Format("1/2"),
-			Weekday: weekdays[date.Weekday()],
+			Weekday: i18n.Default.Weekday(date.Weekday()),
 			Count:   count,
 		})
 	}
 
 	return dailyCommits
 }
-
-// リポジトリが除外リストに含まれているかチェック
-func isRepositoryExcluded(repoName string) bool {
-	for _, excluded := range EXCLUDED_REPOSITORIES {
-		if repoName == excluded {
-			return true
-		}
-	}
-	return false
-}
@@ -1,9 +1,18 @@
 package github
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/google/go-github/v60/github"
 )
 
 // テスト: 変化率計算（正常系・エッジケース）
@@ -347,3 +356,192 @@ func TestGetTargetRange(t *testing.T) {
 		})
 	}
 }
+
+// テスト: 言語ごとのファイル数・変更行数・コミット数の集計
+func TestAccumulateLanguages(t *testing.T) {
+	stats := &WeeklyStats{
+		LanguageCommits: make(map[string]int),
+		LanguageLines:   make(map[string]int),
+		LanguageTouches: make(map[string]int),
+	}
+
+	// コミット1: Go 2ファイル + ロックファイル
+	accumulateLanguages(stats, []*github.CommitFile{
+		{Filename: github.String("main.go"), Additions: github.Int(400), Deletions: github.Int(100)},
+		{Filename: github.String("util.go"), Additions: github.Int(10), Deletions: github.Int(0)},
+		{Filename: github.String("package-lock.json"), Additions: github.Int(3000), Deletions: github.Int(2000)},
+	}, LanguageFilter{ExcludeGenerated: true})
+
+	// コミット2: Go 1ファイル
+	accumulateLanguages(stats, []*github.CommitFile{
+		{Filename: github.String("main.go"), Additions: github.Int(5), Deletions: github.Int(5)},
+	}, LanguageFilter{ExcludeGenerated: true})
+
+	if stats.LanguageCommits["Go"] != 3 {
+		t.Errorf("LanguageCommits[Go]: expected 3, got %d", stats.LanguageCommits["Go"])
+	}
+	if stats.LanguageLines["Go"] != 520 {
+		t.Errorf("LanguageLines[Go]: expected 520, got %d", stats.LanguageLines["Go"])
+	}
+	if stats.LanguageTouches["Go"] != 2 {
+		t.Errorf("LanguageTouches[Go]: expected 2, got %d", stats.LanguageTouches["Go"])
+	}
+	if _, exists := stats.LanguageLines["JavaScript"]; exists {
+		t.Errorf("generated lockfile should be excluded")
+	}
+
+	stats.MainLanguages = filterMainLanguages(stats.LanguageCommits)
+
+	tests := []struct {
+		metric   string
+		expected int
+	}{
+		{metric: LanguageMetricFiles, expected: 3},
+		{metric: LanguageMetricLines, expected: 520},
+		{metric: LanguageMetricCommits, expected: 2},
+		{metric: "", expected: 3},
+	}
+	for _, tt := range tests {
+		if got := stats.MainLanguagesBy(tt.metric)["Go"]; got != tt.expected {
+			t.Errorf("MainLanguagesBy(%q)[Go]: expected %d, got %d", tt.metric, tt.expected, got)
+		}
+	}
+}
+
+// テスト: リポジトリごとの言語・変更行数とメタデータ
+func TestRepoDetailMetadata(t *testing.T) {
+	pushedAt := time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+	repo := &github.Repository{
+		Name:     github.String("api"),
+		Private:  github.Bool(true),
+		Language: github.String("Go"),
+		HTMLURL:  github.String("https://github.com/user/api"),
+		PushedAt: &github.Timestamp{Time: pushedAt},
+	}
+
+	info := map[string]*RepoDetail{"api": newRepoDetail(repo, "api")}
+	accumulateRepoDetail(info["api"], []*github.CommitFile{
+		{Filename: github.String("main.go"), Additions: github.Int(20), Deletions: github.Int(5)},
+		{Filename: github.String("web/app.ts"), Additions: github.Int(10)},
+		{Filename: github.String("vendor/lib/lib.go"), Additions: github.Int(500), Deletions: github.Int(300)},
+	}, LanguageFilter{ExcludeVendored: true})
+	accumulateRepoDetail(info["api"], []*github.CommitFile{
+		{Filename: github.String("handler.go"), Additions: github.Int(2), Deletions: github.Int(1)},
+	}, LanguageFilter{ExcludeVendored: true})
+
+	details := generateRepoDetails(map[string]int{"api": 2, "web": 1})
+	enrichRepoDetails(details, info)
+
+	api := details[0]
+	if api.Name != "api" || api.Count != 2 || api.BarPercent != 100 {
+		t.Fatalf("unexpected detail: %+v", api)
+	}
+	// 除外した vendor/ の変更行数は含めない
+	if api.Additions != 32 || api.Deletions != 6 {
+		t.Errorf("Additions/Deletions: expected 32/6, got %d/%d", api.Additions, api.Deletions)
+	}
+	if api.Languages["Go"] != 2 || api.Languages["TypeScript"] != 1 || len(api.Languages) != 2 {
+		t.Errorf("Languages: unexpected %v", api.Languages)
+	}
+	if !api.Private || api.PrimaryLanguage != "Go" || api.URL != "https://github.com/user/api" || !api.PushedAt.Equal(pushedAt) {
+		t.Errorf("metadata: unexpected %+v", api)
+	}
+
+	// 情報のないリポジトリはそのまま
+	if details[1].Name != "web" || details[1].URL != "" {
+		t.Errorf("web: unexpected %+v", details[1])
+	}
+}
+
+// テスト用の GitHub API（alice の api リポジトリに本人・bot・他人のコミットがある）
+func newFakeGitHubClient(t *testing.T, opts Options) (*Client, *[]string) {
+	t.Helper()
+	startDate, _ := getTargetRange()
+	commitDate := startDate.Add(12 * time.Hour).Format(time.RFC3339)
+	commit := func(sha, login, userType, name, message string) map[string]any {
+		return map[string]any{
+			"sha":    sha,
+			"author": map[string]any{"login": login, "type": userType},
+			"commit": map[string]any{"message": message, "author": map[string]any{"name": name, "date": commitDate}},
+		}
+	}
+
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"login": "alice"})
+	})
+	mux.HandleFunc("/user/repos", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]any{{"name": "api", "pushed_at": time.Now().Format(time.RFC3339)}})
+	})
+	mux.HandleFunc("/repos/alice/api/commits", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		// 本人に紐づくコミット（Actions が本人の名義で作成したものを含む）と、他の作者のコミット
+		commits := []map[string]any{
+			commit("a1", "alice", "User", "Alice", "feat: add endpoint"),
+			commit("a2", "alice", "User", "github-actions[bot]", "chore: update generated docs"),
+		}
+		if r.URL.Query().Get("author") == "" {
+			commits = append(commits,
+				commit("b1", "dependabot[bot]", "Bot", "dependabot[bot]", "chore(deps): bump go-github"),
+				commit("c1", "bob", "User", "Bob", "fix: typo"),
+			)
+		}
+		json.NewEncoder(w).Encode(commits)
+	})
+	mux.HandleFunc("/repos/alice/api/commits/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"files": []map[string]any{{"filename": "main.go", "additions": 10, "deletions": 2}},
+		})
+	})
+	mux.HandleFunc("/repos/alice/api/pulls", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "[]")
+	})
+	mux.HandleFunc("/repos/alice/api/releases", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "[]")
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := NewClientWithOptions("test-token", opts)
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("parse URL: %v", err)
+	}
+	client.ghClient.BaseURL = baseURL
+	return client, &requests
+}
+
+// テスト: 既定では本人のコミットだけを数え、bot の除外は本人に紐づくコミットから差し引く
+func TestFetchWeeklyCommitsExcludesBots(t *testing.T) {
+	tests := []struct {
+		name            string
+		excludeBots     bool
+		expectedCommits int
+		expectedBots    int
+	}{
+		{name: "exclude bots", excludeBots: true, expectedCommits: 1, expectedBots: 1},
+		{name: "default", excludeBots: false, expectedCommits: 2, expectedBots: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := newFakeGitHubClient(t, Options{CommitFilter: &CommitFilter{ExcludeBots: tt.excludeBots}})
+			stats, err := client.FetchWeeklyCommits(context.Background(), "alice")
+			if err != nil {
+				t.Fatalf("FetchWeeklyCommits: %v", err)
+			}
+
+			if stats.TotalCommits != tt.expectedCommits {
+				t.Errorf("TotalCommits: expected %d, got %d", tt.expectedCommits, stats.TotalCommits)
+			}
+			if stats.ExcludedCommits.Bots != tt.expectedBots || stats.ExcludedCommits.Total != tt.expectedBots {
+				t.Errorf("ExcludedCommits: expected %d bots, got %+v", tt.expectedBots, stats.ExcludedCommits)
+			}
+			// 他人や bot のコミットを数えないよう、作者で絞り込む
+			if len(*requests) != 1 || !strings.Contains((*requests)[0], "author=alice") {
+				t.Errorf("commits requests: got %v", *requests)
+			}
+		})
+	}
+}
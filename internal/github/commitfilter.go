@@ -0,0 +1,193 @@
+package github
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// コミットを除外した理由
+const (
+	CommitExcludedBot     = "bot"     // bot のコミット
+	CommitExcludedMessage = "message" // メッセージのパターンに一致
+	CommitExcludedPaths   = "paths"   // 除外パスのファイルのみを変更
+)
+
+// 除外したコミット・ファイルの数
+type ExcludedCommits struct {
+	Total        int `json:"total"`        // 除外したコミット数の合計
+	Bots         int `json:"bots"`         // bot のコミット
+	Messages     int `json:"messages"`     // メッセージのパターンに一致したコミット
+	Paths        int `json:"paths"`        // 除外パスのファイルのみを変更したコミット
+	IgnoredFiles int `json:"ignoredFiles"` // 言語集計から除外したファイル数
+}
+
+// ファイルパスのパターン
+// "vendored" / "generated" / "documentation" は言語判定の分類、"/" で終わる場合はディレクトリ、
+// それ以外はファイルパスまたはファイル名に対する glob
+type PathPattern struct {
+	raw string
+}
+
+// コミットを集計から除外するルール
+type CommitFilter struct {
+	ExcludeBots    bool           // bot のコミットを除外する
+	BotAuthors     []string       // bot として扱う作者（BOT_AUTHORS に追加）
+	ExcludeMessage *regexp.Regexp // 除外するコミットメッセージのパターン
+	ExcludePaths   []PathPattern  // これらのファイルのみを変更したコミットを除外する
+	IgnorePaths    []PathPattern  // 言語集計から除外するファイル（コミットは集計する）
+}
+
+// 環境変数から読み込むルールの設定（リストはカンマ区切り）
+type CommitFilterConfig struct {
+	ExcludeBots    bool   // bot のコミットを除外する
+	BotAuthors     string // bot として扱う作者
+	ExcludeMessage string // 除外するコミットメッセージの正規表現（複数の場合は | で区切る）
+	ExcludePaths   string // これらのファイルのみを変更したコミットを除外する
+	IgnorePaths    string // 言語集計から除外するファイル
+}
+
+// 設定からルールを生成
+func ParseCommitFilter(cfg CommitFilterConfig) (CommitFilter, error) {
+	filter := CommitFilter{
+		ExcludeBots: cfg.ExcludeBots,
+		BotAuthors:  splitList(cfg.BotAuthors),
+	}
+
+	if cfg.ExcludeMessage != "" {
+		re, err := regexp.Compile(cfg.ExcludeMessage)
+		if err != nil {
+			return filter, fmt.Errorf("コミットメッセージの正規表現が不正です %q: %w", cfg.ExcludeMessage, err)
+		}
+		filter.ExcludeMessage = re
+	}
+
+	var err error
+	if filter.ExcludePaths, err = parsePathPatterns(cfg.ExcludePaths); err != nil {
+		return filter, err
+	}
+	if filter.IgnorePaths, err = parsePathPatterns(cfg.IgnorePaths); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// カンマ区切りのパスパターンを解析
+func parsePathPatterns(value string) ([]PathPattern, error) {
+	var patterns []PathPattern
+	for _, raw := range splitList(value) {
+		if _, err := path.Match(raw, ""); err != nil {
+			return nil, fmt.Errorf("パスのパターンが不正です %q: %w", raw, err)
+		}
+		patterns = append(patterns, PathPattern{raw: raw})
+	}
+	return patterns, nil
+}
+
+// ファイルパスに一致するか
+func (p PathPattern) Match(filename string) bool {
+	switch p.raw {
+	case "vendored":
+		return classifyFile(filename).Vendored
+	case "generated":
+		return classifyFile(filename).Generated
+	case "documentation":
+		return classifyFile(filename).Documentation
+	}
+	if strings.HasSuffix(p.raw, "/") {
+		return strings.HasPrefix(filename, p.raw) || strings.Contains(filename, "/"+p.raw)
+	}
+	if matched, _ := path.Match(p.raw, filename); matched {
+		return true
+	}
+	matched, _ := path.Match(p.raw, path.Base(filename))
+	return matched
+}
+
+// いずれかのパターンに一致するか
+func matchAnyPath(patterns []PathPattern, filename string) bool {
+	for _, pattern := range patterns {
+		if pattern.Match(filename) {
+			return true
+		}
+	}
+	return false
+}
+
+// 作者・メッセージから除外するか判定し、理由を返す（除外しない場合は空文字）
+func (f CommitFilter) excludeReason(commit *github.RepositoryCommit) string {
+	if f.ExcludeBots && f.isBot(commit) {
+		return CommitExcludedBot
+	}
+	if f.ExcludeMessage != nil && f.ExcludeMessage.MatchString(commit.GetCommit().GetMessage()) {
+		return CommitExcludedMessage
+	}
+	return ""
+}
+
+// bot のコミットか（GitHub のユーザー種別、または作者名で判定）
+// 名前の一部が一致するだけの人（"renovate-fan" など）を除外しないよう、末尾の "[bot]" か名前の完全一致で判定する
+func (f CommitFilter) isBot(commit *github.RepositoryCommit) bool {
+	if commit.GetAuthor().GetType() == "Bot" {
+		return true
+	}
+	names := []string{
+		strings.ToLower(commit.GetAuthor().GetLogin()),
+		strings.ToLower(commit.GetCommit().GetAuthor().GetName()),
+	}
+	for _, name := range names {
+		if strings.HasSuffix(name, "[bot]") {
+			return true
+		}
+		for _, bot := range slices.Concat(BOT_AUTHORS, f.BotAuthors) {
+			if name != "" && name == strings.ToLower(bot) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 変更ファイルがすべて除外パスに一致するか（ファイルがない場合は false）
+func (f CommitFilter) onlyExcludedPaths(files []*github.CommitFile) bool {
+	if len(f.ExcludePaths) == 0 || len(files) == 0 {
+		return false
+	}
+	for _, file := range files {
+		if !matchAnyPath(f.ExcludePaths, file.GetFilename()) {
+			return false
+		}
+	}
+	return true
+}
+
+// 言語集計の対象ファイルを返し、除外したファイル数を返す
+func (f CommitFilter) languageFiles(files []*github.CommitFile) ([]*github.CommitFile, int) {
+	if len(f.IgnorePaths) == 0 {
+		return files, 0
+	}
+	kept := make([]*github.CommitFile, 0, len(files))
+	for _, file := range files {
+		if !matchAnyPath(f.IgnorePaths, file.GetFilename()) {
+			kept = append(kept, file)
+		}
+	}
+	return kept, len(files) - len(kept)
+}
+
+// 除外したコミットを理由ごとに数える
+func (e *ExcludedCommits) add(reason string) {
+	e.Total++
+	switch reason {
+	case CommitExcludedBot:
+		e.Bots++
+	case CommitExcludedMessage:
+		e.Messages++
+	case CommitExcludedPaths:
+		e.Paths++
+	}
+}
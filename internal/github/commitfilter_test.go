@@ -0,0 +1,95 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// テスト: 作者・メッセージによるコミットの除外
+func TestCommitFilterExcludeReason(t *testing.T) {
+	filter, err := ParseCommitFilter(CommitFilterConfig{
+		ExcludeBots:    true,
+		BotAuthors:     "release-robot",
+		ExcludeMessage: `^(wip|chore\(release\)):`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	commit := func(login, userType, name, message string) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			Author: &github.User{Login: github.String(login), Type: github.String(userType)},
+			Commit: &github.Commit{
+				Author:  &github.CommitAuthor{Name: github.String(name)},
+				Message: github.String(message),
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		commit   *github.RepositoryCommit
+		expected string
+	}{
+		{name: "通常", commit: commit("user", "User", "User", "feat: add api"), expected: ""},
+		{name: "Bot 種別", commit: commit("some-app[bot]", "Bot", "some-app", "update"), expected: CommitExcludedBot},
+		{name: "dependabot", commit: commit("", "", "dependabot[bot]", "Bump x"), expected: CommitExcludedBot},
+		{name: "追加の bot", commit: commit("release-robot", "User", "Release Robot", "release"), expected: CommitExcludedBot},
+		{name: "名前の一部が一致する人", commit: commit("renovate-fan", "User", "Dependabot Fan", "feat: add api"), expected: ""},
+		{name: "追加の bot の名前の一部", commit: commit("release-robot-fan", "User", "User", "feat: add api"), expected: ""},
+		{name: "メッセージ", commit: commit("user", "User", "User", "wip: 途中"), expected: CommitExcludedMessage},
+		{name: "メッセージ（エスケープ）", commit: commit("user", "User", "User", "chore(release): v1.0.0"), expected: CommitExcludedMessage},
+	}
+
+	for _, tt := range tests {
+		if got := filter.excludeReason(tt.commit); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+
+	// ルールがない場合は bot も除外しない
+	if got := (CommitFilter{}).excludeReason(commit("dependabot[bot]", "Bot", "", "Bump")); got != "" {
+		t.Errorf("empty filter: expected no exclusion, got %q", got)
+	}
+}
+
+// テスト: パスによるコミット・ファイルの除外
+func TestCommitFilterPaths(t *testing.T) {
+	filter, err := ParseCommitFilter(CommitFilterConfig{
+		ExcludePaths: "generated,package-lock.json,*.lock",
+		IgnorePaths:  "testdata/,*.snap",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	files := func(names ...string) []*github.CommitFile {
+		var result []*github.CommitFile
+		for _, name := range names {
+			result = append(result, &github.CommitFile{Filename: github.String(name)})
+		}
+		return result
+	}
+
+	// 検証 1: 除外パスのファイルのみのコミット
+	if !filter.onlyExcludedPaths(files("web/package-lock.json", "Cargo.lock", "api/v1.pb.go")) {
+		t.Errorf("lockfile/generated only commit should be excluded")
+	}
+	if filter.onlyExcludedPaths(files("package-lock.json", "main.go")) {
+		t.Errorf("commit with source changes should not be excluded")
+	}
+	if filter.onlyExcludedPaths(nil) {
+		t.Errorf("commit without files should not be excluded")
+	}
+
+	// 検証 2: 言語集計から除外するファイル
+	kept, ignored := filter.languageFiles(files("main.go", "pkg/testdata/input.json", "ui/__snapshots__/app.snap"))
+	if len(kept) != 1 || kept[0].GetFilename() != "main.go" || ignored != 2 {
+		t.Errorf("languageFiles: expected [main.go] and 2 ignored, got %d kept, %d ignored", len(kept), ignored)
+	}
+
+	if _, err := ParseCommitFilter(CommitFilterConfig{ExcludeMessage: "("}); err == nil {
+		t.Errorf("expected error for invalid regexp")
+	}
+}
@@ -54,6 +54,56 @@ var LANGUAGE_MAP = map[string]string{
 	".log":          "Log",
 	".dockerfile":   "Docker",
 	".makefile":     "Makefile",
+	".h":            "C",
+	".hpp":          "C++",
+	".hh":           "C++",
+	".hxx":          "C++",
+	".mjs":          "JavaScript",
+	".cjs":          "JavaScript",
+	".mts":          "TypeScript",
+	".cts":          "TypeScript",
+	".m":            "Objective-C",
+	".mm":           "Objective-C++",
+	".tf":           "HCL",
+	".tfvars":       "HCL",
+	".hcl":          "HCL",
+	".proto":        "Protocol Buffers",
+	".ex":           "Elixir",
+	".exs":          "Elixir",
+	".erl":          "Erlang",
+	".zig":          "Zig",
+	".lua":          "Lua",
+	".r":            "R",
+	".jl":           "Julia",
+	".hs":           "Haskell",
+	".ml":           "OCaml",
+	".clj":          "Clojure",
+	".cljs":         "Clojure",
+	".pl":           "Perl",
+	".pm":           "Perl",
+	".ps1":          "PowerShell",
+	".groovy":       "Groovy",
+	".gradle":       "Groovy",
+	".graphql":      "GraphQL",
+	".gql":          "GraphQL",
+	".nix":          "Nix",
+	".astro":        "Astro",
+	".fish":         "Shell",
+	".bat":          "Batchfile",
+	".cmd":          "Batchfile",
+	".mdx":          "MDX",
+	".rst":          "reStructuredText",
+	".ipynb":        "Jupyter Notebook",
+	".sol":          "Solidity",
+	".v":            "V",
+	".nim":          "Nim",
+	".elm":          "Elm",
+	".fs":           "F#",
+	".fsx":          "F#",
+	".vb":           "Visual Basic .NET",
+	".wasm":         "WebAssembly",
+	".wat":          "WebAssembly",
+	".mjml":         "MJML",
 }
 
 var SPECIAL_LANGUAGE_MAP = map[string]string{
@@ -87,6 +137,105 @@ var SPECIAL_LANGUAGE_MAP = map[string]string{
 	"postcss.config.js":  "JavaScript",
 	"vercel.json":        "Config",
 	"netlify.toml":       "Config",
+	"CMakeLists.txt":     "CMake",
+	"Justfile":           "Just",
+	"Vagrantfile":        "Ruby",
+	"Brewfile":           "Ruby",
+	"Podfile":            "Ruby",
+	"Containerfile":      "Docker",
+	"BUILD":              "Starlark",
+	"BUILD.bazel":        "Starlark",
+	"WORKSPACE":          "Starlark",
+	"go.work":            "Go",
+	"mix.exs":            "Elixir",
+	"build.zig":          "Zig",
+	"wrangler.toml":      "Config",
+	"wrangler.jsonc":     "Config",
+}
+
+// Linguist に倣った vendored 判定用のディレクトリ
+var VENDORED_DIRECTORIES = []string{
+	"vendor",
+	"node_modules",
+	"bower_components",
+	"third_party",
+	"third-party",
+	"3rdparty",
+	".yarn",
+	"Pods",
+	"Carthage",
+}
+
+// Linguist に倣った generated 判定用のディレクトリ（ビルド成果物）
+var GENERATED_DIRECTORIES = []string{
+	"dist",
+	".next",
+	".nuxt",
+	".astro",
+	".wrangler",
+	"coverage",
+	"__generated__",
+}
+
+// 生成ファイルとみなすファイル名の接尾辞
+var GENERATED_SUFFIXES = []string{
+	".pb.go",
+	".pb.gw.go",
+	"_pb2.py",
+	"_pb2_grpc.py",
+	".pb.cc",
+	".pb.h",
+	"_pb.js",
+	"_pb.d.ts",
+	".min.js",
+	".min.css",
+	".map",
+	"_generated.go",
+	".gen.go",
+	"_string.go",
+	".g.dart",
+	".freezed.dart",
+	".designer.cs",
+}
+
+// 生成ファイルとみなすファイル名（ロックファイルなど）
+var GENERATED_FILENAMES = map[string]bool{
+	"package-lock.json": true,
+	"pnpm-lock.yaml":    true,
+	"yarn.lock":         true,
+	"bun.lockb":         true,
+	"go.sum":            true,
+	"Cargo.lock":        true,
+	"Gemfile.lock":      true,
+	"poetry.lock":       true,
+	"Pipfile.lock":      true,
+	"composer.lock":     true,
+	"flake.lock":        true,
+}
+
+// ドキュメントとみなすディレクトリ
+var DOCUMENTATION_DIRECTORIES = []string{
+	"docs",
+	"doc",
+	"Documentation",
+	"javadoc",
+	"examples",
+	"demo",
+	"demos",
+	"man",
+}
+
+// ドキュメントとみなすファイル名（拡張子を除く・大文字小文字を区別しない）
+var DOCUMENTATION_FILENAMES = []string{
+	"readme",
+	"changelog",
+	"changes",
+	"contributing",
+	"copying",
+	"install",
+	"license",
+	"licence",
+	"citation",
 }
 
 // 主要言語の定数定義
@@ -136,3 +285,67 @@ var MAIN_LANGUAGES_SET = func() map[string]bool {
 var EXCLUDED_REPOSITORIES = []string{
 	"obsidian-vault",
 }
+
+// 言語集計の指標
+const (
+	LanguageMetricFiles   = "files"   // 変更ファイル数
+	LanguageMetricLines   = "lines"   // 変更行数（追加+削除）
+	LanguageMetricCommits = "commits" // 言語を含むコミット数
+)
+
+// トレンド比較の設定
+const (
+	TREND_SHORT_WEEKS       = 4  // 短期平均の週数
+	TREND_LONG_WEEKS        = 12 // 長期平均の週数
+	TREND_THRESHOLD_PERCENT = 20 // 平均からこの割合以上変化したら増加・減少とみなす
+	TREND_TOP_ITEMS         = 5  // 表示するリポジトリ・言語の数
+)
+
+// 長期ヒートマップに含める週数（今週を含む）
+const HEATMAP_WEEKS = 12
+
+// チームレポートに表示するリポジトリ数の上限
+const TEAM_TOP_REPOSITORIES = 10
+
+// Conventional Commits の種類（これ以外は COMMIT_TYPE_OTHER として集計する）
+var COMMIT_TYPES = map[string]bool{
+	"feat":     true,
+	"fix":      true,
+	"refactor": true,
+	"docs":     true,
+	"chore":    true,
+	"test":     true,
+	"perf":     true,
+	"style":    true,
+	"build":    true,
+	"ci":       true,
+	"revert":   true,
+}
+
+// Conventional Commits の形式でないコミットの種類
+const COMMIT_TYPE_OTHER = "other"
+
+// 「今週の成果」に表示するコミットの種類
+var SHIPPED_COMMIT_TYPES = map[string]bool{
+	"feat": true,
+	"fix":  true,
+}
+
+// 「今週の成果」に表示する1リポジトリあたりの件数の上限
+const SHIPPED_MAX_ITEMS = 10
+
+// ハイライトに表示する1リポジトリあたりの件数の上限
+const HIGHLIGHTS_PER_REPO = 5
+
+// 目標の達成率を集計する週数（今週を含む）
+const GOAL_SUMMARY_WEEKS = 12
+
+// ホットスポットとして表示するファイル・ディレクトリ数の上限（リポジトリごと）
+const HOTSPOT_TOP_ITEMS = 5
+
+// bot として扱うコミット作者（名前・ログイン名が一致する場合。末尾が "[bot]" の場合は常に bot として扱う）
+var BOT_AUTHORS = []string{
+	"dependabot",
+	"renovate",
+	"github-actions",
+}
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Conventional Commits のヘッダー（type(scope)!: subject）
+var conventionalHeaderPattern = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// 解析したコミットメッセージ
+type ConventionalCommit struct {
+	Type     string // feat, fix など（形式外の場合は other）
+	Scope    string // スコープ（なければ空文字）
+	Subject  string // 件名
+	Breaking bool   // 破壊的変更を含むか
+}
+
+// 「今週の成果」の1項目
+type ShippedItem struct {
+	Type    string `json:"type"`    // feat / fix
+	Scope   string `json:"scope"`   // スコープ
+	Subject string `json:"subject"` // 件名
+}
+
+// リポジトリごとの「今週の成果」
+type ShippedRepo struct {
+	Repo  string        `json:"repo"`  // リポジトリ名
+	Items []ShippedItem `json:"items"` // feat / fix のコミット（重複なし）
+}
+
+// コミットメッセージを Conventional Commits として解析
+func ParseConventionalCommit(message string) ConventionalCommit {
+	header, body, _ := strings.Cut(message, "\n")
+	header = strings.TrimSpace(header)
+
+	match := conventionalHeaderPattern.FindStringSubmatch(header)
+	if match == nil || !COMMIT_TYPES[strings.ToLower(match[1])] {
+		return ConventionalCommit{Type: COMMIT_TYPE_OTHER, Subject: header}
+	}
+
+	return ConventionalCommit{
+		Type:     strings.ToLower(match[1]),
+		Scope:    strings.TrimSpace(match[2]),
+		Subject:  strings.TrimSpace(match[4]),
+		Breaking: match[3] == "!" || strings.Contains(body, "BREAKING CHANGE:"),
+	}
+}
+
+// 1コミット分のメッセージを種類・スコープごとに集計
+func accumulateCommitMessage(stats *WeeklyStats, repoName, message string) {
+	commit := ParseConventionalCommit(message)
+
+	stats.CommitTypes[commit.Type]++
+	if commit.Scope != "" {
+		stats.CommitScopes[commit.Scope]++
+	}
+	if stats.RepoCommitTypes[repoName] == nil {
+		stats.RepoCommitTypes[repoName] = make(map[string]int)
+	}
+	stats.RepoCommitTypes[repoName][commit.Type]++
+
+	if !SHIPPED_COMMIT_TYPES[commit.Type] {
+		return
+	}
+
+	index := slices.IndexFunc(stats.Shipped, func(r ShippedRepo) bool { return r.Repo == repoName })
+	if index < 0 {
+		stats.Shipped = append(stats.Shipped, ShippedRepo{Repo: repoName})
+		index = len(stats.Shipped) - 1
+	}
+	repo := &stats.Shipped[index]
+
+	item := ShippedItem{Type: commit.Type, Scope: commit.Scope, Subject: commit.Subject}
+	if len(repo.Items) >= SHIPPED_MAX_ITEMS || slices.Contains(repo.Items, item) {
+		return
+	}
+	repo.Items = append(repo.Items, item)
+}
+
+// 「今週の成果」をリポジトリ名順、feat → fix の順に並べる
+func sortShipped(shipped []ShippedRepo) {
+	slices.SortFunc(shipped, func(a, b ShippedRepo) int {
+		return strings.Compare(a.Repo, b.Repo)
+	})
+	for _, repo := range shipped {
+		slices.SortStableFunc(repo.Items, func(a, b ShippedItem) int {
+			return strings.Compare(a.Type, b.Type)
+		})
+	}
+}
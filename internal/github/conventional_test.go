@@ -0,0 +1,92 @@
+package github
+
+import (
+	"testing"
+)
+
+// テスト: Conventional Commits の解析
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected ConventionalCommit
+	}{
+		{
+			message:  "feat(auth): ログイン画面を追加",
+			expected: ConventionalCommit{Type: "feat", Scope: "auth", Subject: "ログイン画面を追加"},
+		},
+		{
+			message:  "Fix: typo\n\n本文",
+			expected: ConventionalCommit{Type: "fix", Subject: "typo"},
+		},
+		{
+			message:  "refactor(api)!: レスポンス形式を変更",
+			expected: ConventionalCommit{Type: "refactor", Scope: "api", Subject: "レスポンス形式を変更", Breaking: true},
+		},
+		{
+			message:  "chore: 依存関係を更新\n\nBREAKING CHANGE: Go 1.25 が必要",
+			expected: ConventionalCommit{Type: "chore", Subject: "依存関係を更新", Breaking: true},
+		},
+		{
+			message:  "Merge pull request #1 from user/branch",
+			expected: ConventionalCommit{Type: COMMIT_TYPE_OTHER, Subject: "Merge pull request #1 from user/branch"},
+		},
+		{
+			message:  "wip: 作業中",
+			expected: ConventionalCommit{Type: COMMIT_TYPE_OTHER, Subject: "wip: 作業中"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := ParseConventionalCommit(tt.message); got != tt.expected {
+			t.Errorf("ParseConventionalCommit(%q): expected %+v, got %+v", tt.message, tt.expected, got)
+		}
+	}
+}
+
+// テスト: 種類・スコープの集計と「今週の成果」
+func TestAccumulateCommitMessage(t *testing.T) {
+	stats := &WeeklyStats{
+		CommitTypes:     make(map[string]int),
+		CommitScopes:    make(map[string]int),
+		RepoCommitTypes: make(map[string]map[string]int),
+	}
+
+	accumulateCommitMessage(stats, "web", "fix(ui): ボタンの色を修正")
+	accumulateCommitMessage(stats, "api", "feat(auth): トークン更新")
+	accumulateCommitMessage(stats, "api", "feat(auth): トークン更新") // 重複
+	accumulateCommitMessage(stats, "api", "docs: README を更新")
+	accumulateCommitMessage(stats, "web", "feat: ダークモード")
+	sortShipped(stats.Shipped)
+
+	// 検証 1: 週全体・リポジトリ別の集計
+	if stats.CommitTypes["feat"] != 3 || stats.CommitTypes["fix"] != 1 || stats.CommitTypes["docs"] != 1 {
+		t.Errorf("CommitTypes: unexpected %v", stats.CommitTypes)
+	}
+	if stats.CommitScopes["auth"] != 2 || stats.CommitScopes["ui"] != 1 {
+		t.Errorf("CommitScopes: unexpected %v", stats.CommitScopes)
+	}
+	if stats.RepoCommitTypes["api"]["feat"] != 2 || stats.RepoCommitTypes["web"]["fix"] != 1 {
+		t.Errorf("RepoCommitTypes: unexpected %v", stats.RepoCommitTypes)
+	}
+
+	// 検証 2: feat / fix のみ、リポジトリ名順・重複なし
+	expected := []ShippedRepo{
+		{Repo: "api", Items: []ShippedItem{{Type: "feat", Scope: "auth", Subject: "トークン更新"}}},
+		{Repo: "web", Items: []ShippedItem{{Type: "feat", Subject: "ダークモード"}, {Type: "fix", Scope: "ui", Subject: "ボタンの色を修正"}}},
+	}
+	if len(stats.Shipped) != len(expected) {
+		t.Fatalf("Shipped: expected %d repos, got %d (%+v)", len(expected), len(stats.Shipped), stats.Shipped)
+	}
+	for i, repo := range expected {
+		got := stats.Shipped[i]
+		if got.Repo != repo.Repo || len(got.Items) != len(repo.Items) {
+			t.Errorf("Shipped[%d]: expected %+v, got %+v", i, repo, got)
+			continue
+		}
+		for j, item := range repo.Items {
+			if got.Items[j] != item {
+				t.Errorf("Shipped[%d].Items[%d]: expected %+v, got %+v", i, j, item, got.Items[j])
+			}
+		}
+	}
+}
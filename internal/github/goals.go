@@ -0,0 +1,180 @@
+package github
+
+import (
+	"fmt"
+	"github-weekly-log/internal/i18n"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// 目標に使える指標
+const (
+	GoalCommits    = "commits"     // コミット数
+	GoalActiveDays = "active_days" // 活動日数
+	GoalMergedPRs  = "merged_prs"  // マージされたプルリクエスト数（コミットがあったリポジトリのみ）
+	GoalRepos      = "repos"       // コミットしたリポジトリ数
+	GoalLines      = "lines"       // 変更行数（追加+削除）
+)
+
+// 対応している指標と、週間データから実績を取り出す関数（表示名は i18n のカタログの goal.*）
+// 指標の一覧はここだけで管理し、設定の検証と実績の取得の両方で使う
+var goalExtractors = map[string]func(stats *WeeklyStats) int{
+	GoalCommits:    func(stats *WeeklyStats) int { return stats.TotalCommits },
+	GoalActiveDays: func(stats *WeeklyStats) int { return stats.ActiveDays },
+	GoalMergedPRs:  func(stats *WeeklyStats) int { return stats.MergedPullRequests },
+	GoalRepos:      func(stats *WeeklyStats) int { return len(stats.RepoDetails) },
+	GoalLines: func(stats *WeeklyStats) int {
+		lines := 0
+		for _, repo := range stats.RepoDetails {
+			lines += repo.Additions + repo.Deletions
+		}
+		return lines
+	},
+}
+
+// 週ごとの目標
+type Goal struct {
+	Metric string // 指標（GoalCommits など）
+	Target int    // 目標値（この値以上で達成）
+}
+
+// 1週間分の目標の達成状況
+type GoalResult struct {
+	Metric   string `json:"metric"`   // 指標
+	Label    string `json:"label"`    // 表示名
+	Target   int    `json:"target"`   // 目標値
+	Actual   int    `json:"actual"`   // 実績
+	Percent  int    `json:"percent"`  // 達成率（%、100が上限）
+	Achieved bool   `json:"achieved"` // 目標を達成したか
+}
+
+// 直近の週での目標の達成率
+type GoalSummary struct {
+	Metric   string `json:"metric"`   // 指標
+	Label    string `json:"label"`    // 表示名
+	Weeks    int    `json:"weeks"`    // 目標があった週数（今週を含む）
+	Achieved int    `json:"achieved"` // 達成した週数
+	HitRate  int    `json:"hitRate"`  // 達成率（%）
+}
+
+// 指標の表示名（既定の言語、未知の指標はそのまま返す）
+func goalLabel(metric string) string {
+	if _, exists := goalExtractors[metric]; !exists {
+		return metric
+	}
+	return i18n.Default.Label("goal", metric)
+}
+
+// 環境変数の値から目標を生成する
+// spec: "active_days:5,commits:30,merged_prs:1"
+func ParseGoals(spec string) ([]Goal, error) {
+	var goals []Goal
+	seen := make(map[string]bool)
+	for _, entry := range splitList(spec) {
+		metric, value, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("目標の形式が不正です: %q", entry)
+		}
+		metric = strings.ToLower(strings.TrimSpace(metric))
+		if _, exists := goalExtractors[metric]; !exists {
+			return nil, fmt.Errorf("未対応の指標です: %q（%s）", metric, strings.Join(slices.Sorted(maps.Keys(goalExtractors)), ", "))
+		}
+		if seen[metric] {
+			return nil, fmt.Errorf("指標が重複しています: %q", metric)
+		}
+		target, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || target <= 0 {
+			return nil, fmt.Errorf("%s の目標値が不正です: %q", metric, value)
+		}
+		seen[metric] = true
+		goals = append(goals, Goal{Metric: metric, Target: target})
+	}
+	return goals, nil
+}
+
+// 週間データから指標の実績を取得（未知の指標は 0）
+func goalActual(stats *WeeklyStats, metric string) int {
+	extract, exists := goalExtractors[metric]
+	if !exists {
+		return 0
+	}
+	return extract(stats)
+}
+
+// 週間データに対して目標の達成状況を判定
+func EvaluateGoals(stats *WeeklyStats, goals []Goal) []GoalResult {
+	var results []GoalResult
+	for _, goal := range goals {
+		actual := goalActual(stats, goal.Metric)
+		percent := 100
+		if actual < goal.Target {
+			percent = int(math.Floor(float64(actual) / float64(goal.Target) * 100))
+		}
+		results = append(results, GoalResult{
+			Metric:   goal.Metric,
+			Label:    goalLabel(goal.Metric),
+			Target:   goal.Target,
+			Actual:   actual,
+			Percent:  percent,
+			Achieved: actual >= goal.Target,
+		})
+	}
+	return results
+}
+
+// 今週を含む直近 weeks 週の目標の達成率を計算
+// 過去の週は保存済みの達成状況を使う（当時の目標値で判定されたもの）
+// 目標がなかった週は数えないため、週数は指標ごとに異なることがある
+func SummarizeGoals(history []*WeeklyStats, comparison *WeeklyComparison, weeks int) []GoalSummary {
+	current := comparison.CurrentWeek
+	if len(current.Goals) == 0 {
+		return nil
+	}
+
+	// 開始日ごとに週をまとめる（先週は取得したばかりのデータを優先）
+	byStart := make(map[string]*WeeklyStats)
+	for _, week := range history {
+		byStart[week.StartDate.Format("2006-01-02")] = week
+	}
+	if comparison.PreviousWeek != nil {
+		byStart[comparison.PreviousWeek.StartDate.Format("2006-01-02")] = comparison.PreviousWeek
+	}
+	byStart[current.StartDate.Format("2006-01-02")] = current
+
+	var summaries []GoalSummary
+	for _, goal := range current.Goals {
+		summary := GoalSummary{Metric: goal.Metric, Label: goal.Label}
+		for i := 0; i < weeks; i++ {
+			week, exists := byStart[current.StartDate.AddDate(0, 0, -7*i).Format("2006-01-02")]
+			if !exists {
+				continue
+			}
+			result, exists := findGoalResult(week.Goals, goal.Metric)
+			if !exists {
+				continue
+			}
+			summary.Weeks++
+			if result.Achieved {
+				summary.Achieved++
+			}
+		}
+		if summary.Weeks > 0 {
+			summary.HitRate = int(math.Round(float64(summary.Achieved) / float64(summary.Weeks) * 100))
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// 指標の達成状況を検索
+func findGoalResult(results []GoalResult, metric string) (GoalResult, bool) {
+	for _, result := range results {
+		if result.Metric == metric {
+			return result, true
+		}
+	}
+	return GoalResult{}, false
+}
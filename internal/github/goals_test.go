@@ -0,0 +1,128 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+// テスト: 目標の設定の解析
+func TestParseGoals(t *testing.T) {
+	goals, err := ParseGoals("active_days:5, commits:30,merged_prs:1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Goal{{GoalActiveDays, 5}, {GoalCommits, 30}, {GoalMergedPRs, 1}}
+	if len(goals) != len(expected) {
+		t.Fatalf("expected %d goals, got %d", len(expected), len(goals))
+	}
+	for i, goal := range goals {
+		if goal != expected[i] {
+			t.Errorf("goals[%d]: expected %+v, got %+v", i, expected[i], goal)
+		}
+	}
+
+	if goals, err := ParseGoals(""); err != nil || goals != nil {
+		t.Errorf("empty spec: expected no goals, got %v, %v", goals, err)
+	}
+
+	for _, spec := range []string{"commits", "stars:10", "commits:0", "commits:abc", "commits:1,commits:2", "active-days:5"} {
+		if _, err := ParseGoals(spec); err == nil {
+			t.Errorf("%q: expected error", spec)
+		}
+	}
+}
+
+// テスト: 対応している指標には表示名がある
+func TestGoalExtractorsHaveLabels(t *testing.T) {
+	for metric := range goalExtractors {
+		if goalLabel(metric) == metric {
+			t.Errorf("%s: label is missing", metric)
+		}
+		if _, err := ParseGoals(metric + ":1"); err != nil {
+			t.Errorf("%s: unexpected error: %v", metric, err)
+		}
+	}
+}
+
+// テスト: 目標の達成状況の判定
+func TestEvaluateGoals(t *testing.T) {
+	stats := &WeeklyStats{
+		TotalCommits:       12,
+		ActiveDays:         5,
+		MergedPullRequests: 0,
+		RepoDetails: []RepoDetail{
+			{Name: "api", Additions: 120, Deletions: 30},
+			{Name: "web", Additions: 40, Deletions: 10},
+		},
+	}
+	goals := []Goal{{GoalCommits, 30}, {GoalActiveDays, 5}, {GoalMergedPRs, 1}, {GoalRepos, 2}, {GoalLines, 100}}
+
+	tests := []struct {
+		actual   int
+		percent  int
+		achieved bool
+	}{
+		{actual: 12, percent: 40, achieved: false},
+		{actual: 5, percent: 100, achieved: true},
+		{actual: 0, percent: 0, achieved: false},
+		{actual: 2, percent: 100, achieved: true},
+		{actual: 200, percent: 100, achieved: true},
+	}
+
+	results := EvaluateGoals(stats, goals)
+	if len(results) != len(tests) {
+		t.Fatalf("expected %d results, got %d", len(tests), len(results))
+	}
+	for i, tt := range tests {
+		got := results[i]
+		if got.Actual != tt.actual || got.Percent != tt.percent || got.Achieved != tt.achieved {
+			t.Errorf("%s: expected %d (%d%%, %v), got %d (%d%%, %v)",
+				got.Metric, tt.actual, tt.percent, tt.achieved, got.Actual, got.Percent, got.Achieved)
+		}
+	}
+	if results[0].Label != "コミット数" {
+		t.Errorf("Label: expected コミット数, got %s", results[0].Label)
+	}
+}
+
+// テスト: 直近の週での目標の達成率
+func TestSummarizeGoals(t *testing.T) {
+	start := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+	week := func(weeksAgo int, goals ...GoalResult) *WeeklyStats {
+		return &WeeklyStats{StartDate: start.AddDate(0, 0, -7*weeksAgo), Goals: goals}
+	}
+	hit := GoalResult{Metric: GoalCommits, Achieved: true}
+	miss := GoalResult{Metric: GoalCommits}
+
+	comparison := &WeeklyComparison{
+		CurrentWeek: week(0, GoalResult{Metric: GoalCommits, Label: "コミット数", Achieved: true},
+			GoalResult{Metric: GoalMergedPRs, Label: "マージされたPR"}),
+		PreviousWeek: week(1, miss),
+	}
+	history := []*WeeklyStats{
+		week(1, hit), // 先週は取得したばかりのデータを優先
+		week(2, hit),
+		week(3), // 目標がなかった週は数えない
+		week(4, hit),
+		week(5, hit), // 集計範囲外
+	}
+
+	summaries := SummarizeGoals(history, comparison, 5)
+	expected := []GoalSummary{
+		{Metric: GoalCommits, Label: "コミット数", Weeks: 4, Achieved: 3, HitRate: 75},
+		{Metric: GoalMergedPRs, Label: "マージされたPR", Weeks: 1, Achieved: 0, HitRate: 0},
+	}
+	if len(summaries) != len(expected) {
+		t.Fatalf("expected %d summaries, got %d", len(expected), len(summaries))
+	}
+	for i, summary := range summaries {
+		if summary != expected[i] {
+			t.Errorf("summaries[%d]: expected %+v, got %+v", i, expected[i], summary)
+		}
+	}
+
+	// 目標がない場合は集計しない
+	if got := SummarizeGoals(history, &WeeklyComparison{CurrentWeek: week(0)}, 5); got != nil {
+		t.Errorf("expected nil without goals, got %+v", got)
+	}
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"github-weekly-log/internal/i18n"
+	"time"
+)
+
+// 曜日×時間帯のコミット数（[time.Weekday][時]、0=日曜日）
+type Heatmap [7][24]int
+
+// ヒートマップの1マス
+type HeatmapCell struct {
+	Hour  int // 時（0-23）
+	Count int // コミット数
+	Level int // 色の濃さ（0-4、最大値に対する割合で決定）
+}
+
+// ヒートマップの1行（1曜日分）
+type HeatmapRow struct {
+	Day     time.Weekday  // 曜日
+	Weekday string        // "月", "火" など（既定の言語）
+	Cells   []HeatmapCell // 24時間分
+	Total   int           // その曜日の合計
+}
+
+// 表示順（月曜始まり）
+var heatmapWeekdayOrder = []int{1, 2, 3, 4, 5, 6, 0}
+
+// 合計コミット数
+func (h *Heatmap) Total() int {
+	total := 0
+	for _, hours := range h {
+		for _, count := range hours {
+			total += count
+		}
+	}
+	return total
+}
+
+// 別のヒートマップを加算
+func (h *Heatmap) Add(other *Heatmap) {
+	for weekday := range h {
+		for hour := range h[weekday] {
+			h[weekday][hour] += other[weekday][hour]
+		}
+	}
+}
+
+// テンプレート用に月曜始まりの行へ変換し、色の濃さを計算する
+func (h *Heatmap) Rows() []HeatmapRow {
+	maxCount := 0
+	for _, hours := range h {
+		for _, count := range hours {
+			maxCount = max(maxCount, count)
+		}
+	}
+
+	rows := make([]HeatmapRow, 0, 7)
+	for _, weekday := range heatmapWeekdayOrder {
+		day := time.Weekday(weekday)
+		row := HeatmapRow{Day: day, Weekday: i18n.Default.Weekday(day), Cells: make([]HeatmapCell, 0, 24)}
+		for hour, count := range h[weekday] {
+			row.Cells = append(row.Cells, HeatmapCell{
+				Hour:  hour,
+				Count: count,
+				Level: relativeHeatLevel(count, maxCount),
+			})
+			row.Total += count
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// 最大値に対する割合から色の濃さ（0-4）を決める（メールの日別の色分けは email の固定の閾値）
+func relativeHeatLevel(count, maxCount int) int {
+	if count <= 0 || maxCount <= 0 {
+		return 0
+	}
+	// 1〜4 の4段階（切り上げ）
+	return (count*4 + maxCount - 1) / maxCount
+}
+
+// 今週と過去の週のヒートマップを合算する（長期的な傾向用）
+// weeks は今週を含めた週数の上限
+func AggregateHeatmap(history []*WeeklyStats, current *WeeklyStats, weeks int) (*Heatmap, int) {
+	aggregated := &Heatmap{}
+	aggregated.Add(&current.WeekdayHourlyActivity)
+	count := 1
+
+	oldest := current.StartDate.AddDate(0, 0, -7*(weeks-1))
+	for _, week := range history {
+		if week.StartDate.Before(oldest) || !week.StartDate.Before(current.StartDate) {
+			continue
+		}
+		aggregated.Add(&week.WeekdayHourlyActivity)
+		count++
+	}
+	return aggregated, count
+}
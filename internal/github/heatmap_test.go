@@ -0,0 +1,76 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+// テスト: ヒートマップの行変換と色の濃さ
+func TestHeatmapRows(t *testing.T) {
+	var heatmap Heatmap
+	heatmap[time.Monday][9] = 8
+	heatmap[time.Monday][10] = 2
+	heatmap[time.Sunday][23] = 1
+
+	rows := heatmap.Rows()
+
+	// 検証 1: 月曜始まりで7行・24列
+	if len(rows) != 7 {
+		t.Fatalf("Rows: expected 7, got %d", len(rows))
+	}
+	if rows[0].Weekday != "月" || rows[6].Weekday != "日" {
+		t.Errorf("Order: expected 月..日, got %s..%s", rows[0].Weekday, rows[6].Weekday)
+	}
+	for _, row := range rows {
+		if len(row.Cells) != 24 {
+			t.Errorf("%s: expected 24 cells, got %d", row.Weekday, len(row.Cells))
+		}
+	}
+
+	// 検証 2: 最大値に対する色の濃さ
+	tests := []struct {
+		row, hour     int
+		expectedLevel int
+	}{
+		{row: 0, hour: 9, expectedLevel: 4},
+		{row: 0, hour: 10, expectedLevel: 1},
+		{row: 6, hour: 23, expectedLevel: 1},
+		{row: 3, hour: 12, expectedLevel: 0},
+	}
+	for _, tt := range tests {
+		if got := rows[tt.row].Cells[tt.hour].Level; got != tt.expectedLevel {
+			t.Errorf("rows[%d][%d] level: expected %d, got %d", tt.row, tt.hour, tt.expectedLevel, got)
+		}
+	}
+
+	if rows[0].Total != 10 || heatmap.Total() != 11 {
+		t.Errorf("Total: expected 10 / 11, got %d / %d", rows[0].Total, heatmap.Total())
+	}
+}
+
+// テスト: 複数週のヒートマップの合算
+func TestAggregateHeatmap(t *testing.T) {
+	start := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+
+	current := &WeeklyStats{StartDate: start}
+	current.WeekdayHourlyActivity[time.Friday][22] = 3
+
+	var history []*WeeklyStats
+	for i := 1; i <= 4; i++ {
+		week := &WeeklyStats{StartDate: start.AddDate(0, 0, -7*i)}
+		week.WeekdayHourlyActivity[time.Friday][22] = 1
+		history = append(history, week)
+	}
+
+	// 今週を含めて3週分のみ合算される
+	aggregated, weeks := AggregateHeatmap(history, current, 3)
+	if weeks != 3 {
+		t.Errorf("weeks: expected 3, got %d", weeks)
+	}
+	if aggregated[time.Friday][22] != 5 {
+		t.Errorf("Friday 22時: expected 5, got %d", aggregated[time.Friday][22])
+	}
+	if current.WeekdayHourlyActivity[time.Friday][22] != 3 {
+		t.Errorf("current week should not be modified")
+	}
+}
@@ -0,0 +1,149 @@
+package github
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// ハイライトの種類
+const (
+	HighlightRelease     = "release"      // リリース
+	HighlightPullRequest = "pull_request" // マージされたプルリクエスト
+	HighlightCommit      = "commit"       // コミットの件名
+)
+
+// スカッシュマージのコミット件名に付く PR 番号（"title (#12)"）
+var pullRequestSuffixPattern = regexp.MustCompile(`\s*\(#\d+\)$`)
+
+// ハイライトの1項目
+type Highlight struct {
+	Kind  string `json:"kind"`  // release / pull_request / commit
+	Title string `json:"title"` // リリース名・PRタイトル・コミットの件名
+	URL   string `json:"url"`   // リンク先（コミットの場合は空）
+}
+
+// リポジトリごとのハイライト
+type RepoHighlights struct {
+	Repo  string      `json:"repo"`  // リポジトリ名
+	Items []Highlight `json:"items"` // リリース → PR → コミットの順
+}
+
+// ハイライトの収集元（リポジトリごと）
+type highlightSources struct {
+	releases []Highlight
+	pulls    []Highlight
+	commits  []Highlight
+}
+
+// マージコミットかどうか（親が複数、または既定のマージメッセージ）
+func isMergeCommit(commit *github.RepositoryCommit) bool {
+	if len(commit.Parents) > 1 {
+		return true
+	}
+	subject := commitSubject(commit.GetCommit().GetMessage())
+	return strings.HasPrefix(subject, "Merge pull request ") || strings.HasPrefix(subject, "Merge branch ")
+}
+
+// コミットメッセージの1行目
+func commitSubject(message string) string {
+	subject, _, _ := strings.Cut(message, "\n")
+	return strings.TrimSpace(subject)
+}
+
+// 重複判定用にタイトルを正規化
+func normalizeHighlightTitle(title string) string {
+	title = pullRequestSuffixPattern.ReplaceAllString(title, "")
+	return strings.ToLower(strings.TrimSpace(title))
+}
+
+// リポジトリごとにリリース・PR・コミットをまとめ、重複を除いて上位 limit 件に絞る
+// リポジトリの並び順は order に従う
+func buildHighlights(order []string, sources map[string]*highlightSources, limit int) []RepoHighlights {
+	var highlights []RepoHighlights
+	for _, repo := range order {
+		source, exists := sources[repo]
+		if !exists {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		var items []Highlight
+		for _, group := range [][]Highlight{source.releases, source.pulls, source.commits} {
+			for _, item := range group {
+				key := normalizeHighlightTitle(item.Title)
+				if key == "" || seen[key] || len(items) >= limit {
+					continue
+				}
+				seen[key] = true
+				items = append(items, item)
+			}
+		}
+
+		if len(items) > 0 {
+			highlights = append(highlights, RepoHighlights{Repo: repo, Items: items})
+		}
+	}
+	return highlights
+}
+
+// 期間内にマージされた自分のプルリクエストを取得
+func (c *Client) fetchMergedPullRequests(ctx context.Context, owner, repo, author string, since, until time.Time) ([]Highlight, error) {
+	opts := &github.PullRequestListOptions{
+		State:       "closed",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var highlights []Highlight
+	for {
+		pulls, resp, err := c.ghClient.PullRequests.List(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, pull := range pulls {
+			// 更新日時の降順なので、期間より前に更新されたものが出たら終了
+			if pull.GetUpdatedAt().Before(since) {
+				return highlights, nil
+			}
+			mergedAt := pull.GetMergedAt().Time
+			if mergedAt.IsZero() || mergedAt.Before(since) || !mergedAt.Before(until) {
+				continue
+			}
+			if !strings.EqualFold(pull.GetUser().GetLogin(), author) {
+				continue
+			}
+			highlights = append(highlights, Highlight{Kind: HighlightPullRequest, Title: pull.GetTitle(), URL: pull.GetHTMLURL()})
+		}
+		if resp.NextPage == 0 {
+			return highlights, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// 期間内に公開されたリリースを取得
+func (c *Client) fetchReleases(ctx context.Context, owner, repo string, since, until time.Time) ([]Highlight, error) {
+	releases, _, err := c.ghClient.Repositories.ListReleases(ctx, owner, repo, &github.ListOptions{PerPage: 30})
+	if err != nil {
+		return nil, err
+	}
+
+	var highlights []Highlight
+	for _, release := range releases {
+		publishedAt := release.GetPublishedAt().Time
+		if release.GetDraft() || publishedAt.Before(since) || !publishedAt.Before(until) {
+			continue
+		}
+		title := release.GetTagName()
+		if name := release.GetName(); name != "" && name != title {
+			title += " " + name
+		}
+		highlights = append(highlights, Highlight{Kind: HighlightRelease, Title: title, URL: release.GetHTMLURL()})
+	}
+	return highlights, nil
+}
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// テスト: リポジトリごとのハイライト生成
+func TestBuildHighlights(t *testing.T) {
+	sources := map[string]*highlightSources{
+		"api": {
+			releases: []Highlight{{Kind: HighlightRelease, Title: "v1.2.0"}},
+			pulls:    []Highlight{{Kind: HighlightPullRequest, Title: "Add login API"}},
+			commits: []Highlight{
+				{Kind: HighlightCommit, Title: "Add login API (#12)"}, // PRのスカッシュマージと重複
+				{Kind: HighlightCommit, Title: "fix typo"},
+				{Kind: HighlightCommit, Title: "Fix typo"}, // 大文字小文字違いの重複
+				{Kind: HighlightCommit, Title: "update deps"},
+				{Kind: HighlightCommit, Title: "refactor handler"},
+				{Kind: HighlightCommit, Title: "add tests"},
+			},
+		},
+		"web":   {commits: []Highlight{{Kind: HighlightCommit, Title: "dark mode"}}},
+		"empty": {commits: []Highlight{{Kind: HighlightCommit, Title: " "}}},
+	}
+
+	highlights := buildHighlights([]string{"web", "api", "empty", "unknown"}, sources, 4)
+
+	// 検証 1: order の順で、空のリポジトリは含まれない
+	if len(highlights) != 2 || highlights[0].Repo != "web" || highlights[1].Repo != "api" {
+		t.Fatalf("unexpected repos: %+v", highlights)
+	}
+
+	// 検証 2: リリース → PR → コミットの順で重複を除いて上限まで
+	expected := []string{"v1.2.0", "Add login API", "fix typo", "update deps"}
+	items := highlights[1].Items
+	if len(items) != len(expected) {
+		t.Fatalf("api items: expected %d, got %d (%+v)", len(expected), len(items), items)
+	}
+	for i, title := range expected {
+		if items[i].Title != title {
+			t.Errorf("api items[%d]: expected %q, got %q", i, title, items[i].Title)
+		}
+	}
+}
+
+// テスト: マージコミットの判定
+func TestIsMergeCommit(t *testing.T) {
+	commit := func(message string, parents int) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			Commit:  &github.Commit{Message: github.String(message)},
+			Parents: make([]*github.Commit, parents),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		commit   *github.RepositoryCommit
+		expected bool
+	}{
+		{name: "通常のコミット", commit: commit("feat: add api", 1), expected: false},
+		{name: "親が2つ", commit: commit("Sync with main", 2), expected: true},
+		{name: "PRのマージ", commit: commit("Merge pull request #3 from user/feature", 1), expected: true},
+		{name: "ブランチのマージ", commit: commit("Merge branch 'main' into feature", 1), expected: true},
+	}
+
+	for _, tt := range tests {
+		if got := isMergeCommit(tt.commit); got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
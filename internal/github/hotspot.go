@@ -0,0 +1,113 @@
+package github
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// リポジトリ直下のファイルをまとめるディレクトリ名
+const rootDirectory = "/"
+
+// よく変更されたファイル・ディレクトリ
+type Hotspot struct {
+	Path    string `json:"path"`    // ファイルパス / トップレベルのディレクトリ名
+	Changes int    `json:"changes"` // 変更されたコミット数
+	Churn   int    `json:"churn"`   // 変更行数（追加+削除）
+}
+
+// リポジトリごとのホットスポット
+type RepoHotspots struct {
+	Repo        string    `json:"repo"`        // リポジトリ名
+	Files       []Hotspot `json:"files"`       // 変更回数の多いファイル
+	Directories []Hotspot `json:"directories"` // 変更回数の多いトップレベルのディレクトリ
+}
+
+// ホットスポットの集計（リポジトリごと）
+type hotspotCounter struct {
+	files       map[string]*Hotspot
+	directories map[string]*Hotspot
+}
+
+// 1コミット分の変更ファイルをホットスポットとして集計
+func accumulateHotspots(counters map[string]*hotspotCounter, repoName string, files []*github.CommitFile, filter LanguageFilter) {
+	counter, exists := counters[repoName]
+	if !exists {
+		counter = &hotspotCounter{files: make(map[string]*Hotspot), directories: make(map[string]*Hotspot)}
+		counters[repoName] = counter
+	}
+
+	touchedDirs := make(map[string]bool)
+	for _, file := range files {
+		path := file.GetFilename()
+		if filter.Excludes(classifyFile(path)) {
+			continue
+		}
+		churn := file.GetAdditions() + file.GetDeletions()
+
+		addHotspot(counter.files, path, churn)
+
+		dir := rootDirectory
+		if top, _, found := strings.Cut(path, "/"); found {
+			dir = top
+		}
+		// ディレクトリの変更回数は1コミットにつき1回
+		hotspot := addHotspot(counter.directories, dir, churn)
+		if touchedDirs[dir] {
+			hotspot.Changes--
+		}
+		touchedDirs[dir] = true
+	}
+}
+
+// ホットスポットの変更回数と変更行数を加算
+func addHotspot(hotspots map[string]*Hotspot, path string, churn int) *Hotspot {
+	hotspot, exists := hotspots[path]
+	if !exists {
+		hotspot = &Hotspot{Path: path}
+		hotspots[path] = hotspot
+	}
+	hotspot.Changes++
+	hotspot.Churn += churn
+	return hotspot
+}
+
+// 変更回数 → 変更行数 → パスの順で並べ、上位 limit 件を返す
+func topHotspots(hotspots map[string]*Hotspot, limit int) []Hotspot {
+	items := make([]Hotspot, 0, len(hotspots))
+	for _, hotspot := range hotspots {
+		items = append(items, *hotspot)
+	}
+	slices.SortFunc(items, func(a, b Hotspot) int {
+		if a.Changes != b.Changes {
+			return b.Changes - a.Changes
+		}
+		if a.Churn != b.Churn {
+			return b.Churn - a.Churn
+		}
+		return cmp.Compare(a.Path, b.Path)
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// リポジトリごとのホットスポットを order の順に生成
+func buildHotspots(order []string, counters map[string]*hotspotCounter, limit int) []RepoHotspots {
+	var hotspots []RepoHotspots
+	for _, repo := range order {
+		counter, exists := counters[repo]
+		if !exists || len(counter.files) == 0 {
+			continue
+		}
+		hotspots = append(hotspots, RepoHotspots{
+			Repo:        repo,
+			Files:       topHotspots(counter.files, limit),
+			Directories: topHotspots(counter.directories, limit),
+		})
+	}
+	return hotspots
+}
@@ -0,0 +1,56 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// テスト: ファイル・ディレクトリのホットスポット集計
+func TestAccumulateHotspots(t *testing.T) {
+	file := func(name string, additions, deletions int) *github.CommitFile {
+		return &github.CommitFile{
+			Filename:  github.String(name),
+			Additions: github.Int(additions),
+			Deletions: github.Int(deletions),
+		}
+	}
+
+	counters := make(map[string]*hotspotCounter)
+	accumulateHotspots(counters, "api", []*github.CommitFile{
+		file("internal/server/handler.go", 10, 2),
+		file("internal/server/router.go", 3, 0),
+		file("go.sum", 50, 50),
+	}, LanguageFilter{ExcludeGenerated: true})
+	accumulateHotspots(counters, "api", []*github.CommitFile{
+		file("internal/server/handler.go", 5, 5),
+		file("README.md", 1, 0),
+	}, LanguageFilter{ExcludeGenerated: true})
+
+	hotspots := buildHotspots([]string{"api", "web"}, counters, 2)
+	if len(hotspots) != 1 {
+		t.Fatalf("expected 1 repo, got %d (%+v)", len(hotspots), hotspots)
+	}
+
+	// 検証 1: 変更回数 → 変更行数の順、除外ファイル（go.sum）は含まれない
+	expectedFiles := []Hotspot{
+		{Path: "internal/server/handler.go", Changes: 2, Churn: 22},
+		{Path: "internal/server/router.go", Changes: 1, Churn: 3},
+	}
+	for i, expected := range expectedFiles {
+		if hotspots[0].Files[i] != expected {
+			t.Errorf("Files[%d]: expected %+v, got %+v", i, expected, hotspots[0].Files[i])
+		}
+	}
+
+	// 検証 2: ディレクトリは1コミットにつき1回、直下のファイルは "/" にまとめる
+	expectedDirs := []Hotspot{
+		{Path: "internal", Changes: 2, Churn: 25},
+		{Path: "/", Changes: 1, Churn: 1},
+	}
+	for i, expected := range expectedDirs {
+		if hotspots[0].Directories[i] != expected {
+			t.Errorf("Directories[%d]: expected %+v, got %+v", i, expected, hotspots[0].Directories[i])
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package github
+
+import (
+	"path"
+	"strings"
+)
+
+// ファイルの分類結果（GitHub Linguist に倣った判定）
+type FileClass struct {
+	Language      string // 言語名（判定できない場合は "Other"）
+	Vendored      bool   // vendor/ や node_modules/ などの外部コード
+	Generated     bool   // 生成ファイル（*.pb.go やロックファイル、dist/ 配下など）
+	Documentation bool   // ドキュメント（docs/ や README など）
+}
+
+// 言語集計から除外するファイルの種類
+type LanguageFilter struct {
+	ExcludeVendored      bool // vendored ファイルを除外する
+	ExcludeGenerated     bool // 生成ファイルを除外する
+	ExcludeDocumentation bool // ドキュメントを除外する
+}
+
+// 言語集計の対象外かどうか
+func (f LanguageFilter) Excludes(class FileClass) bool {
+	return (f.ExcludeVendored && class.Vendored) ||
+		(f.ExcludeGenerated && class.Generated) ||
+		(f.ExcludeDocumentation && class.Documentation)
+}
+
+// "vendored,generated,documentation" 形式の文字列から LanguageFilter を生成
+func ParseLanguageFilter(value string) LanguageFilter {
+	var filter LanguageFilter
+	for _, kind := range strings.Split(value, ",") {
+		switch strings.ToLower(strings.TrimSpace(kind)) {
+		case "vendored":
+			filter.ExcludeVendored = true
+		case "generated":
+			filter.ExcludeGenerated = true
+		case "documentation":
+			filter.ExcludeDocumentation = true
+		}
+	}
+	return filter
+}
+
+// ファイルパスから言語と種類を判定する
+// 判定順序は Linguist と同様に ファイル名 → 拡張子 → パスのヒューリスティック
+func classifyFile(filename string) FileClass {
+	// GitHub API のパスは常に "/" 区切り
+	filename = strings.TrimPrefix(filename, "./")
+	baseName := path.Base(filename)
+	dir := path.Dir(filename)
+
+	class := FileClass{
+		Language:      detectLanguage(baseName),
+		Vendored:      hasDirectory(dir, VENDORED_DIRECTORIES),
+		Generated:     isGeneratedFile(dir, baseName),
+		Documentation: isDocumentationFile(dir, baseName),
+	}
+
+	return class
+}
+
+// ファイル名から言語を判定
+func detectLanguage(baseName string) string {
+	// 特殊なファイル名を確認する
+	if lang, exists := SPECIAL_LANGUAGE_MAP[baseName]; exists {
+		return lang
+	}
+
+	// Dockerfile.dev のような派生ファイル名
+	if strings.HasPrefix(baseName, "Dockerfile") || strings.HasPrefix(baseName, "Containerfile") {
+		return "Docker"
+	}
+
+	// 拡張子を確認する
+	ext := strings.ToLower(path.Ext(baseName))
+	if lang, exists := LANGUAGE_MAP[ext]; exists {
+		return lang
+	}
+
+	// 拡張子のない dotfile（.gitignore など）は拡張子扱いで再確認する
+	if lang, exists := LANGUAGE_MAP[strings.ToLower(baseName)]; exists {
+		return lang
+	}
+
+	return "Other"
+}
+
+// パスのいずれかの階層が指定ディレクトリに一致するか
+func hasDirectory(dir string, directories []string) bool {
+	if dir == "." || dir == "" {
+		return false
+	}
+	for _, segment := range strings.Split(dir, "/") {
+		for _, target := range directories {
+			if segment == target {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 生成ファイルかどうか
+func isGeneratedFile(dir, baseName string) bool {
+	if GENERATED_FILENAMES[baseName] {
+		return true
+	}
+	// zz_generated.deepcopy.go のような Kubernetes の生成コード
+	if strings.HasPrefix(baseName, "zz_generated") {
+		return true
+	}
+	lower := strings.ToLower(baseName)
+	for _, suffix := range GENERATED_SUFFIXES {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	return hasDirectory(dir, GENERATED_DIRECTORIES)
+}
+
+// ドキュメントかどうか
+func isDocumentationFile(dir, baseName string) bool {
+	// README, README.md, LICENSE.txt など（license.go のような名前は除く）
+	name, _, _ := strings.Cut(strings.ToLower(baseName), ".")
+	for _, docName := range DOCUMENTATION_FILENAMES {
+		if name == docName && !MAIN_LANGUAGES_SET[detectLanguage(baseName)] {
+			return true
+		}
+	}
+	return hasDirectory(dir, DOCUMENTATION_DIRECTORIES)
+}
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"testing"
+)
+
+// テスト: ファイルの言語・種類判定
+func TestClassifyFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		expected FileClass
+	}{
+		{name: "Goのソース", filename: "internal/github/client.go", expected: FileClass{Language: "Go"}},
+		{name: "Cヘッダー", filename: "src/util.h", expected: FileClass{Language: "C"}},
+		{name: "ESモジュール", filename: "scripts/build.mjs", expected: FileClass{Language: "JavaScript"}},
+		{name: "CommonJS", filename: "config.cjs", expected: FileClass{Language: "JavaScript"}},
+		{name: "Terraform", filename: "infra/main.tf", expected: FileClass{Language: "HCL"}},
+		{name: "Protocol Buffers", filename: "api/user.proto", expected: FileClass{Language: "Protocol Buffers"}},
+		{name: "Elixir", filename: "lib/app.ex", expected: FileClass{Language: "Elixir"}},
+		{name: "Zig", filename: "src/main.zig", expected: FileClass{Language: "Zig"}},
+		{name: "Lua", filename: "init.lua", expected: FileClass{Language: "Lua"}},
+		{name: "R（大文字拡張子）", filename: "analysis.R", expected: FileClass{Language: "R"}},
+		{name: "ファイル名が拡張子より優先", filename: "CMakeLists.txt", expected: FileClass{Language: "CMake"}},
+		{name: "Dockerfileの派生", filename: "docker/Dockerfile.dev", expected: FileClass{Language: "Docker"}},
+		{name: "不明な拡張子", filename: "data.xyz", expected: FileClass{Language: "Other"}},
+		{name: "vendor配下", filename: "vendor/github.com/foo/bar.go", expected: FileClass{Language: "Go", Vendored: true}},
+		{name: "node_modules配下", filename: "web/node_modules/react/index.js", expected: FileClass{Language: "JavaScript", Vendored: true}},
+		{name: "protoc生成コード", filename: "api/user.pb.go", expected: FileClass{Language: "Go", Generated: true}},
+		{name: "dist配下", filename: "frontend/dist/app.js", expected: FileClass{Language: "JavaScript", Generated: true}},
+		{name: "ロックファイル", filename: "pnpm-lock.yaml", expected: FileClass{Language: "JavaScript", Generated: true}},
+		{name: "ミニファイ済み", filename: "public/app.min.js", expected: FileClass{Language: "JavaScript", Generated: true}},
+		{name: "README", filename: "README.md", expected: FileClass{Language: "Markdown", Documentation: true}},
+		{name: "LICENSE", filename: "LICENSE", expected: FileClass{Language: "Other", Documentation: true}},
+		{name: "docs配下", filename: "docs/guide/setup.md", expected: FileClass{Language: "Markdown", Documentation: true}},
+		{name: "license.goはソース扱い", filename: "pkg/license.go", expected: FileClass{Language: "Go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyFile(tt.filename)
+			if got != tt.expected {
+				t.Errorf("classifyFile(%q): expected %+v, got %+v", tt.filename, tt.expected, got)
+			}
+		})
+	}
+}
+
+// テスト: 除外設定の解析と適用
+func TestLanguageFilter(t *testing.T) {
+	filter := ParseLanguageFilter("vendored, Generated")
+
+	if !filter.ExcludeVendored || !filter.ExcludeGenerated || filter.ExcludeDocumentation {
+		t.Fatalf("ParseLanguageFilter: unexpected result %+v", filter)
+	}
+
+	if !filter.Excludes(FileClass{Language: "Go", Vendored: true}) {
+		t.Errorf("vendored file should be excluded")
+	}
+	if filter.Excludes(FileClass{Language: "Markdown", Documentation: true}) {
+		t.Errorf("documentation should not be excluded")
+	}
+	if filter.Excludes(FileClass{Language: "Go"}) {
+		t.Errorf("source file should not be excluded")
+	}
+}
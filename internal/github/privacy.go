@@ -0,0 +1,280 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// プライベートリポジトリの表示方法
+const (
+	PrivacyShow  = "show"  // そのまま表示
+	PrivacyHide  = "hide"  // 出力から除外
+	PrivacyHash  = "hash"  // ハッシュ化した名前で表示
+	PrivacyAlias = "alias" // 別名で表示（別名がない場合はハッシュ化）
+)
+
+// 出力先ごとのプライバシー設定
+type PrivacyPolicy struct {
+	PrivateRepos string            // プライベートリポジトリの表示方法（show / hide / hash / alias）
+	Aliases      map[string]string // alias の場合の別名（リポジトリ名 → 表示名）
+	DropMessages bool              // コミットメッセージ・PRタイトルを出力しない
+}
+
+// 環境変数の値からプライバシー設定を生成する
+// spec: "hash", "alias,drop-messages" など / aliases: "secret-api:Project A,internal-tool:Tool B"
+func ParsePrivacyPolicy(spec, aliases string) (PrivacyPolicy, error) {
+	policy := PrivacyPolicy{PrivateRepos: PrivacyShow, Aliases: make(map[string]string)}
+
+	for _, option := range strings.Split(spec, ",") {
+		switch option = strings.TrimSpace(strings.ToLower(option)); option {
+		case "":
+		case PrivacyShow, PrivacyHide, PrivacyHash, PrivacyAlias:
+			policy.PrivateRepos = option
+		case "drop-messages":
+			policy.DropMessages = true
+		default:
+			return policy, fmt.Errorf("不明なプライバシー設定です: %q", option)
+		}
+	}
+
+	for _, pair := range strings.Split(aliases, ",") {
+		name, alias, ok := strings.Cut(pair, ":")
+		if !ok {
+			continue
+		}
+		policy.Aliases[strings.TrimSpace(name)] = strings.TrimSpace(alias)
+	}
+
+	return policy, nil
+}
+
+// プライベートリポジトリの名前を変換する
+// hide の場合は false を返す
+func (p PrivacyPolicy) maskName(name string, private map[string]bool) (string, bool) {
+	if !private[name] {
+		return name, true
+	}
+	switch p.PrivateRepos {
+	case PrivacyHide:
+		return "", false
+	case PrivacyHash:
+		return hashRepoName(name), true
+	case PrivacyAlias:
+		if alias, exists := p.Aliases[name]; exists {
+			return alias, true
+		}
+		// チームの "owner/repo" 形式はリポジトリ名でも別名を探す
+		if _, repo, found := strings.Cut(name, "/"); found {
+			if alias, exists := p.Aliases[repo]; exists {
+				return alias, true
+			}
+		}
+		return hashRepoName(name), true
+	default:
+		return name, true
+	}
+}
+
+// リポジトリ名をハッシュ化（同じ名前は常に同じ値になる）
+func hashRepoName(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return "private-" + hex.EncodeToString(sum[:])[:8]
+}
+
+// 設定を適用した比較データのコピーを返す（元のデータは変更しない）
+func (p PrivacyPolicy) Apply(comparison *WeeklyComparison) *WeeklyComparison {
+	private := make(map[string]bool)
+	for _, week := range []*WeeklyStats{comparison.CurrentWeek, comparison.PreviousWeek} {
+		collectPrivateRepos(week, private)
+	}
+
+	masked := *comparison
+	masked.CurrentWeek = p.applyStats(comparison.CurrentWeek, private)
+	masked.PreviousWeek = p.applyStats(comparison.PreviousWeek, private)
+
+	if comparison.Trends != nil {
+		trends := *comparison.Trends
+		trends.Repos = nil
+		for _, item := range comparison.Trends.Repos {
+			if name, ok := p.maskName(item.Name, private); ok {
+				item.Name = name
+				trends.Repos = append(trends.Repos, item)
+			}
+		}
+		masked.Trends = &trends
+	}
+	return &masked
+}
+
+// 設定を適用して保存した履歴の名前を元に戻したコピーを返す（元のデータは変更しない）
+// 今週の取得時に見つかったプライベートリポジトリの名前を変換して照合するため、
+// 削除されたリポジトリなど元に戻せない名前は変換後のまま残る
+func (p PrivacyPolicy) UnmaskHistory(history []*WeeklyStats, comparison *WeeklyComparison) []*WeeklyStats {
+	private := make(map[string]bool)
+	for _, week := range []*WeeklyStats{comparison.CurrentWeek, comparison.PreviousWeek} {
+		collectPrivateRepos(week, private)
+	}
+	original := make(map[string]string)
+	for name := range private {
+		if masked, ok := p.maskName(name, private); ok && masked != name {
+			original[masked] = name
+		}
+	}
+	if len(original) == 0 {
+		return history
+	}
+
+	unmasked := make([]*WeeklyStats, 0, len(history))
+	for _, week := range history {
+		week := *week
+		details := make([]RepoDetail, 0, len(week.RepoDetails))
+		for _, repo := range week.RepoDetails {
+			if name, exists := original[repo.Name]; exists {
+				repo.Name = name
+			}
+			details = append(details, repo)
+		}
+		week.RepoDetails = details
+		unmasked = append(unmasked, &week)
+	}
+	return unmasked
+}
+
+// 設定を適用したチームレポートのコピーを返す（元のデータは変更しない）
+func (p PrivacyPolicy) ApplyTeam(report *TeamReport) *TeamReport {
+	masked := &TeamReport{}
+
+	private := make(map[string]bool)
+	for _, member := range report.Members {
+		for _, week := range []*WeeklyStats{member.Comparison.CurrentWeek, member.Comparison.PreviousWeek} {
+			collectTeamPrivateRepos(week, member.Username, private)
+		}
+		masked.Members = append(masked.Members, MemberReport{
+			Username:   member.Username,
+			Comparison: p.Apply(member.Comparison),
+		})
+	}
+
+	if report.Team != nil {
+		team := *report.Team
+		team.RepoDetails = p.maskRepoDetails(report.Team.RepoDetails, private)
+		masked.Team = &team
+	}
+	return masked
+}
+
+// プライベートリポジトリの名前を集める
+func collectPrivateRepos(stats *WeeklyStats, private map[string]bool) {
+	if stats == nil {
+		return
+	}
+	for name := range stats.privateRepos {
+		private[name] = true
+	}
+	for _, repo := range stats.RepoDetails {
+		if repo.Private {
+			private[repo.Name] = true
+		}
+	}
+}
+
+// チーム集計の "owner/repo" 形式でプライベートリポジトリの名前を集める
+func collectTeamPrivateRepos(stats *WeeklyStats, username string, private map[string]bool) {
+	member := make(map[string]bool)
+	collectPrivateRepos(stats, member)
+	for name := range member {
+		private[teamRepoName(username, name)] = true
+	}
+}
+
+// 1週間分のデータに設定を適用したコピーを返す
+func (p PrivacyPolicy) applyStats(stats *WeeklyStats, private map[string]bool) *WeeklyStats {
+	if stats == nil {
+		return nil
+	}
+	masked := *stats
+
+	masked.RepoDetails = p.maskRepoDetails(stats.RepoDetails, private)
+
+	masked.RepoCommitTypes = make(map[string]map[string]int)
+	for repo, types := range stats.RepoCommitTypes {
+		if name, ok := p.maskName(repo, private); ok {
+			masked.RepoCommitTypes[name] = types
+		}
+	}
+
+	// スコープはコミットメッセージから集計するため、メッセージを出力しない場合は除外する
+	if p.DropMessages {
+		masked.CommitScopes = make(map[string]int)
+	}
+
+	// 件名はコミットメッセージそのものなので、メッセージを出力しない場合は除外する
+	masked.Shipped = nil
+	if !p.DropMessages {
+		for _, repo := range stats.Shipped {
+			if name, ok := p.maskName(repo.Repo, private); ok {
+				repo.Repo = name
+				masked.Shipped = append(masked.Shipped, repo)
+			}
+		}
+	}
+
+	masked.Highlights = nil
+	for _, repo := range stats.Highlights {
+		name, ok := p.maskName(repo.Repo, private)
+		if !ok {
+			continue
+		}
+		var items []Highlight
+		for _, item := range repo.Items {
+			if p.DropMessages && item.Kind != HighlightRelease {
+				continue
+			}
+			if name != repo.Repo {
+				item.URL = "" // リンクから元の名前が分かるため
+			}
+			items = append(items, item)
+		}
+		if len(items) > 0 {
+			masked.Highlights = append(masked.Highlights, RepoHighlights{Repo: name, Items: items})
+		}
+	}
+
+	masked.Hotspots = nil
+	for _, repo := range stats.Hotspots {
+		if name, ok := p.maskName(repo.Repo, private); ok {
+			repo.Repo = name
+			masked.Hotspots = append(masked.Hotspots, repo)
+		}
+	}
+
+	return &masked
+}
+
+// RepoDetail の名前を変換し、名前を変えた場合は URL を除いてプライベートとして扱う
+// 除外したリポジトリがある場合は、残ったリポジトリの最大コミット数を基準にバー幅を計算し直す
+func (p PrivacyPolicy) maskRepoDetails(details []RepoDetail, private map[string]bool) []RepoDetail {
+	var masked []RepoDetail
+	maxCount := 0
+	for _, repo := range details {
+		name, ok := p.maskName(repo.Name, private)
+		if !ok {
+			continue
+		}
+		if name != repo.Name {
+			repo.Name = name
+			repo.URL = ""
+			repo.Private = true
+		}
+		masked = append(masked, repo)
+		maxCount = max(maxCount, repo.Count)
+	}
+	if len(masked) < len(details) && maxCount > 0 {
+		for i := range masked {
+			masked[i].BarPercent = float64(masked[i].Count) / float64(maxCount) * 100
+		}
+	}
+	return masked
+}
@@ -0,0 +1,203 @@
+package github
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// テスト用: プライベートリポジトリを含む比較データ
+func newPrivacyTestComparison() *WeeklyComparison {
+	current := &WeeklyStats{
+		RepoDetails: []RepoDetail{
+			{Name: "secret-api", Count: 5, Private: true, URL: "https://github.com/user/secret-api"},
+			{Name: "blog", Count: 2, URL: "https://github.com/user/blog"},
+		},
+		RepoCommitTypes: map[string]map[string]int{"secret-api": {"feat": 1}, "blog": {"fix": 1}},
+		Shipped: []ShippedRepo{
+			{Repo: "secret-api", Items: []ShippedItem{{Type: "feat", Subject: "顧客Aの請求処理"}}},
+			{Repo: "blog", Items: []ShippedItem{{Type: "fix", Subject: "typo"}}},
+		},
+		Highlights: []RepoHighlights{
+			{Repo: "secret-api", Items: []Highlight{
+				{Kind: HighlightRelease, Title: "v1.0.0", URL: "https://github.com/user/secret-api/releases/v1.0.0"},
+				{Kind: HighlightCommit, Title: "顧客Aの請求処理"},
+			}},
+		},
+		CommitScopes: map[string]int{"billing": 1},
+		Hotspots:     []RepoHotspots{{Repo: "secret-api"}},
+		privateRepos: map[string]bool{"old-secret": true},
+	}
+	return &WeeklyComparison{
+		CurrentWeek:  current,
+		PreviousWeek: &WeeklyStats{RepoDetails: []RepoDetail{{Name: "old-secret", Count: 1}}},
+		Trends:       &Trends{Repos: []ItemTrend{{Name: "old-secret"}, {Name: "blog"}}},
+	}
+}
+
+// テスト: プライベートリポジトリの表示方法
+func TestPrivacyPolicyApply(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          string
+		expectedNames []string // 今週の RepoDetails の名前
+		expectedTrend string   // Trends.Repos[0] の名前
+	}{
+		{name: "show", spec: "", expectedNames: []string{"secret-api", "blog"}, expectedTrend: "old-secret"},
+		{name: "hide", spec: "hide", expectedNames: []string{"blog"}, expectedTrend: "blog"},
+		{name: "hash", spec: "hash", expectedNames: []string{hashRepoName("secret-api"), "blog"}, expectedTrend: hashRepoName("old-secret")},
+		{name: "alias", spec: "alias", expectedNames: []string{"Project A", "blog"}, expectedTrend: hashRepoName("old-secret")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParsePrivacyPolicy(tt.spec, "secret-api:Project A")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			original := newPrivacyTestComparison()
+			masked := policy.Apply(original)
+
+			var names []string
+			for _, repo := range masked.CurrentWeek.RepoDetails {
+				names = append(names, repo.Name)
+				// 名前を変えたリポジトリは URL から元の名前が分からないようにする
+				if repo.Private && repo.Name != "secret-api" && repo.URL != "" {
+					t.Errorf("%s: URL should be removed, got %s", repo.Name, repo.URL)
+				}
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expectedNames, ",") {
+				t.Errorf("RepoDetails: expected %v, got %v", tt.expectedNames, names)
+			}
+			if masked.Trends.Repos[0].Name != tt.expectedTrend {
+				t.Errorf("Trends: expected %s, got %s", tt.expectedTrend, masked.Trends.Repos[0].Name)
+			}
+			if _, exists := masked.CurrentWeek.RepoCommitTypes["secret-api"]; exists != (tt.spec == "") {
+				t.Errorf("RepoCommitTypes[secret-api]: exists=%v", exists)
+			}
+
+			// 元のデータは変更されない
+			if original.CurrentWeek.RepoDetails[0].Name != "secret-api" || original.Trends.Repos[0].Name != "old-secret" {
+				t.Errorf("original should not be modified")
+			}
+		})
+	}
+}
+
+// テスト: コミットメッセージの除外
+func TestPrivacyPolicyDropMessages(t *testing.T) {
+	policy, err := ParsePrivacyPolicy("hash,drop-messages", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	masked := policy.Apply(newPrivacyTestComparison())
+
+	if len(masked.CurrentWeek.Shipped) != 0 {
+		t.Errorf("Shipped should be dropped, got %+v", masked.CurrentWeek.Shipped)
+	}
+	if len(masked.CurrentWeek.CommitScopes) != 0 {
+		t.Errorf("CommitScopes should be dropped, got %+v", masked.CurrentWeek.CommitScopes)
+	}
+	highlights := masked.CurrentWeek.Highlights
+	if len(highlights) != 1 || len(highlights[0].Items) != 1 || highlights[0].Items[0].Kind != HighlightRelease {
+		t.Fatalf("only releases should remain, got %+v", highlights)
+	}
+	if highlights[0].Repo != hashRepoName("secret-api") || highlights[0].Items[0].URL != "" {
+		t.Errorf("private highlight should be masked, got %+v", highlights[0])
+	}
+
+	if _, err := ParsePrivacyPolicy("encrypt", ""); err == nil {
+		t.Errorf("expected error for unknown option")
+	}
+}
+
+// テスト: チームレポートの "owner/repo" 形式
+func TestPrivacyPolicyApplyTeam(t *testing.T) {
+	policy, _ := ParsePrivacyPolicy("alias", "secret-api:Project A")
+	report := &TeamReport{
+		Members: []MemberReport{{Username: "alice", Comparison: newPrivacyTestComparison()}},
+		Team: &TeamStats{RepoDetails: []RepoDetail{
+			{Name: "alice/secret-api", Count: 5},
+			{Name: "alice/blog", Count: 2},
+		}},
+	}
+
+	masked := policy.ApplyTeam(report)
+	team := masked.Team.RepoDetails
+	if team[0].Name != "Project A" || !team[0].Private || team[1].Name != "alice/blog" {
+		t.Errorf("unexpected team repos: %+v", team)
+	}
+	if masked.Members[0].Comparison.CurrentWeek.RepoDetails[0].Name != "Project A" {
+		t.Errorf("member comparison should be masked")
+	}
+	if report.Team.RepoDetails[0].Name != "alice/secret-api" {
+		t.Errorf("original should not be modified")
+	}
+}
+
+// テスト: hide で除外した場合は残ったリポジトリでバー幅を計算し直す
+func TestPrivacyPolicyHideBarPercent(t *testing.T) {
+	policy, err := ParsePrivacyPolicy("hide", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	comparison := newPrivacyTestComparison()
+	comparison.CurrentWeek.RepoDetails = generateRepoDetails(map[string]int{"secret-api": 8, "blog": 4, "docs": 2})
+	comparison.CurrentWeek.privateRepos["secret-api"] = true
+
+	masked := policy.Apply(comparison)
+	details := masked.CurrentWeek.RepoDetails
+	if len(details) != 2 || details[0].Name != "blog" || details[0].BarPercent != 100 || details[1].BarPercent != 50 {
+		t.Errorf("unexpected details: %+v", details)
+	}
+	if comparison.CurrentWeek.RepoDetails[1].BarPercent != 50 {
+		t.Errorf("original should not be modified")
+	}
+}
+
+// テスト: 名前を変換して保存した履歴を元の名前に戻してトレンドを計算する
+func TestPrivacyPolicyUnmaskHistory(t *testing.T) {
+	comparison := newPrivacyTestComparison()
+	start := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+	comparison.CurrentWeek.StartDate = start
+	comparison.PreviousWeek.StartDate = start.AddDate(0, 0, -7)
+	comparison.PreviousWeek.RepoDetails = append(comparison.PreviousWeek.RepoDetails, RepoDetail{Name: "secret-api", Count: 5, Private: true})
+
+	for _, spec := range []string{"hash", "alias"} {
+		t.Run(spec, func(t *testing.T) {
+			policy, err := ParsePrivacyPolicy(spec, "secret-api:Project A")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// D1 には変換後の名前で保存されている
+			var history []*WeeklyStats
+			for i := 2; i <= 4; i++ {
+				week := policy.Apply(&WeeklyComparison{CurrentWeek: &WeeklyStats{
+					StartDate:   start.AddDate(0, 0, -7*i),
+					RepoDetails: []RepoDetail{{Name: "secret-api", Count: 5, Private: true}, {Name: "deleted", Count: 1}},
+				}}).CurrentWeek
+				week.RepoDetails = append(week.RepoDetails, RepoDetail{Name: hashRepoName("deleted-secret"), Count: 1})
+				history = append(history, week)
+			}
+
+			unmasked := policy.UnmaskHistory(history, comparison)
+			if unmasked[0].RepoDetails[0].Name != "secret-api" || unmasked[0].RepoDetails[2].Name != hashRepoName("deleted-secret") {
+				t.Errorf("unexpected names: %+v", unmasked[0].RepoDetails)
+			}
+			if history[0].RepoDetails[0].Name == "secret-api" {
+				t.Errorf("history should not be modified")
+			}
+
+			// 同じリポジトリが元の名前と変換後の名前で重複しない
+			trends := CalculateTrends(unmasked, comparison)
+			for _, item := range trends.Repos {
+				if item.Name == "Project A" || item.Name == hashRepoName("secret-api") {
+					t.Errorf("masked name should be matched to secret-api, got %+v", trends.Repos)
+				}
+				if item.Name == "secret-api" && item.Direction != TrendFlat {
+					t.Errorf("secret-api: expected flat, got %+v", item)
+				}
+			}
+		})
+	}
+}
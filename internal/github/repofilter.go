@@ -0,0 +1,190 @@
+package github
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// 除外ルール名
+const (
+	RepoRuleInclude  = "include"  // 明示的な対象リストに含まれない
+	RepoRuleOwner    = "owner"    // 対象の所有者ではない
+	RepoRuleName     = "name"     // 名前のパターンに一致
+	RepoRuleTopic    = "topic"    // 除外するトピックを持つ
+	RepoRuleFork     = "fork"     // フォーク
+	RepoRuleArchived = "archived" // アーカイブ済み
+	RepoRuleTemplate = "template" // テンプレートリポジトリ
+)
+
+// リポジトリ名のパターン（glob または /正規表現/）
+type RepoPattern struct {
+	raw    string
+	regexp *regexp.Regexp
+}
+
+// 集計対象のリポジトリを決めるルール
+type RepoFilter struct {
+	Include          []RepoPattern // 指定した場合、一致するリポジトリのみを対象にする
+	Owners           []string      // 指定した場合、これらの所有者のリポジトリのみを対象にする
+	ExcludeNames     []RepoPattern // 除外するリポジトリ名
+	ExcludeTopics    []string      // 除外するトピック
+	ExcludeForks     bool          // フォークを除外する
+	ExcludeArchived  bool          // アーカイブ済みを除外する
+	ExcludeTemplates bool          // テンプレートリポジトリを除外する
+}
+
+// リポジトリごとの判定結果
+type RepoDecision struct {
+	Repo     string `json:"repo"`     // "owner/repo" 形式
+	Included bool   `json:"included"` // 集計対象か
+	Rule     string `json:"rule"`     // 除外したルール（"name:tmp-*" など、対象の場合は空）
+}
+
+// パターンを解析（"/.../" は正規表現、それ以外は glob）
+func ParseRepoPattern(raw string) (RepoPattern, error) {
+	raw = strings.TrimSpace(raw)
+	if len(raw) > 2 && strings.HasPrefix(raw, "/") && strings.HasSuffix(raw, "/") {
+		re, err := regexp.Compile(raw[1 : len(raw)-1])
+		if err != nil {
+			return RepoPattern{}, fmt.Errorf("正規表現が不正です %q: %w", raw, err)
+		}
+		return RepoPattern{raw: raw, regexp: re}, nil
+	}
+	if _, err := path.Match(raw, ""); err != nil {
+		return RepoPattern{}, fmt.Errorf("glob パターンが不正です %q: %w", raw, err)
+	}
+	return RepoPattern{raw: raw}, nil
+}
+
+// カンマ区切りのパターンを解析
+func ParseRepoPatterns(value string) ([]RepoPattern, error) {
+	var patterns []RepoPattern
+	for _, raw := range splitList(value) {
+		pattern, err := ParseRepoPattern(raw)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// リポジトリに一致するか（"/" を含むパターンは "owner/repo" と比較する）
+func (p RepoPattern) Match(owner, name string) bool {
+	target := name
+	if strings.Contains(strings.Trim(p.raw, "/"), "/") {
+		target = owner + "/" + name
+	}
+	if p.regexp != nil {
+		return p.regexp.MatchString(target)
+	}
+	matched, _ := path.Match(p.raw, target)
+	return matched
+}
+
+func (p RepoPattern) String() string {
+	return p.raw
+}
+
+// 既定のルール（EXCLUDED_REPOSITORIES の完全一致）
+func DefaultRepoFilter() RepoFilter {
+	var filter RepoFilter
+	for _, name := range EXCLUDED_REPOSITORIES {
+		filter.ExcludeNames = append(filter.ExcludeNames, RepoPattern{raw: name})
+	}
+	return filter
+}
+
+// リポジトリを判定する（最初に一致したルールで除外する）
+func (f RepoFilter) Evaluate(repo *github.Repository) RepoDecision {
+	owner := repo.GetOwner().GetLogin()
+	name := repo.GetName()
+	decision := RepoDecision{Repo: owner + "/" + name}
+
+	exclude := func(rule string) RepoDecision {
+		decision.Rule = rule
+		return decision
+	}
+
+	if len(f.Include) > 0 && !slices.ContainsFunc(f.Include, func(p RepoPattern) bool { return p.Match(owner, name) }) {
+		return exclude(RepoRuleInclude)
+	}
+	if len(f.Owners) > 0 && !slices.ContainsFunc(f.Owners, func(o string) bool { return strings.EqualFold(o, owner) }) {
+		return exclude(RepoRuleOwner)
+	}
+	for _, pattern := range f.ExcludeNames {
+		if pattern.Match(owner, name) {
+			return exclude(RepoRuleName + ":" + pattern.String())
+		}
+	}
+	for _, topic := range f.ExcludeTopics {
+		if slices.Contains(repo.Topics, topic) {
+			return exclude(RepoRuleTopic + ":" + topic)
+		}
+	}
+	if f.ExcludeForks && repo.GetFork() {
+		return exclude(RepoRuleFork)
+	}
+	if f.ExcludeArchived && repo.GetArchived() {
+		return exclude(RepoRuleArchived)
+	}
+	if f.ExcludeTemplates && repo.GetIsTemplate() {
+		return exclude(RepoRuleTemplate)
+	}
+
+	decision.Included = true
+	return decision
+}
+
+// カンマ区切りの値を分割（空の要素は除く）
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// 環境変数から読み込むルールの設定（リストはカンマ区切り）
+type RepoFilterConfig struct {
+	Include          string // 対象にするリポジトリのパターン
+	Owners           string // 対象にする所有者
+	Exclude          string // 除外するリポジトリのパターン（空の場合は EXCLUDED_REPOSITORIES）
+	ExcludeTopics    string // 除外するトピック
+	ExcludeForks     bool   // フォークを除外する
+	ExcludeArchived  bool   // アーカイブ済みを除外する
+	ExcludeTemplates bool   // テンプレートリポジトリを除外する
+}
+
+// 設定からルールを生成
+func ParseRepoFilter(cfg RepoFilterConfig) (RepoFilter, error) {
+	filter := DefaultRepoFilter()
+
+	include, err := ParseRepoPatterns(cfg.Include)
+	if err != nil {
+		return filter, err
+	}
+	filter.Include = include
+
+	if cfg.Exclude != "" {
+		exclude, err := ParseRepoPatterns(cfg.Exclude)
+		if err != nil {
+			return filter, err
+		}
+		filter.ExcludeNames = exclude
+	}
+
+	filter.Owners = splitList(cfg.Owners)
+	filter.ExcludeTopics = splitList(cfg.ExcludeTopics)
+	filter.ExcludeForks = cfg.ExcludeForks
+	filter.ExcludeArchived = cfg.ExcludeArchived
+	filter.ExcludeTemplates = cfg.ExcludeTemplates
+	return filter, nil
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// テスト用のリポジトリ
+func newTestRepository(owner, name string, modify func(*github.Repository)) *github.Repository {
+	repo := &github.Repository{
+		Name:  github.String(name),
+		Owner: &github.User{Login: github.String(owner)},
+	}
+	if modify != nil {
+		modify(repo)
+	}
+	return repo
+}
+
+// テスト: ルールによるリポジトリの判定
+func TestRepoFilterEvaluate(t *testing.T) {
+	filter, err := ParseRepoFilter(RepoFilterConfig{
+		Owners:           "user,my-org",
+		Exclude:          "tmp-*,/^sandbox-\\d+$/,my-org/legacy",
+		ExcludeTopics:    "no-report",
+		ExcludeForks:     true,
+		ExcludeArchived:  true,
+		ExcludeTemplates: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		repo     *github.Repository
+		expected string // 除外ルール（対象の場合は空）
+	}{
+		{name: "対象", repo: newTestRepository("user", "api", nil), expected: ""},
+		{name: "所有者", repo: newTestRepository("other", "api", nil), expected: RepoRuleOwner},
+		{name: "glob", repo: newTestRepository("user", "tmp-test", nil), expected: "name:tmp-*"},
+		{name: "正規表現", repo: newTestRepository("user", "sandbox-12", nil), expected: `name:/^sandbox-\d+$/`},
+		{name: "正規表現に不一致", repo: newTestRepository("user", "sandbox-a", nil), expected: ""},
+		{name: "owner/repo 形式", repo: newTestRepository("my-org", "legacy", nil), expected: "name:my-org/legacy"},
+		{name: "別の所有者の同名", repo: newTestRepository("user", "legacy", nil), expected: ""},
+		{name: "トピック", repo: newTestRepository("user", "notes", func(r *github.Repository) { r.Topics = []string{"go", "no-report"} }), expected: "topic:no-report"},
+		{name: "フォーク", repo: newTestRepository("user", "fork", func(r *github.Repository) { r.Fork = github.Bool(true) }), expected: RepoRuleFork},
+		{name: "アーカイブ", repo: newTestRepository("user", "old", func(r *github.Repository) { r.Archived = github.Bool(true) }), expected: RepoRuleArchived},
+		{name: "テンプレート", repo: newTestRepository("user", "tpl", func(r *github.Repository) { r.IsTemplate = github.Bool(true) }), expected: RepoRuleTemplate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := filter.Evaluate(tt.repo)
+			if decision.Included != (tt.expected == "") || decision.Rule != tt.expected {
+				t.Errorf("expected rule %q, got %+v", tt.expected, decision)
+			}
+		})
+	}
+}
+
+// テスト: 明示的な対象リストと既定のルール
+func TestRepoFilterIncludeAndDefault(t *testing.T) {
+	filter, err := ParseRepoFilter(RepoFilterConfig{Include: "api,web-*"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !filter.Evaluate(newTestRepository("user", "web-front", nil)).Included {
+		t.Errorf("web-front should be included")
+	}
+	if decision := filter.Evaluate(newTestRepository("user", "blog", nil)); decision.Included || decision.Rule != RepoRuleInclude {
+		t.Errorf("blog should be excluded by include rule, got %+v", decision)
+	}
+
+	// REPO_EXCLUDE が空の場合は EXCLUDED_REPOSITORIES を除外する
+	if decision := DefaultRepoFilter().Evaluate(newTestRepository("user", EXCLUDED_REPOSITORIES[0], nil)); decision.Included {
+		t.Errorf("%s should be excluded by default", EXCLUDED_REPOSITORIES[0])
+	}
+
+	if _, err := ParseRepoFilter(RepoFilterConfig{Exclude: "/[/"}); err == nil {
+		t.Errorf("expected error for invalid regexp")
+	}
+}
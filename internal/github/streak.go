@@ -0,0 +1,81 @@
+package github
+
+import (
+	"time"
+)
+
+// 継続記録（連続活動日数・週数）
+type Streaks struct {
+	CurrentDays  int `json:"currentDays"`  // 週の最終日時点で続いている連続活動日数
+	LongestDays  int `json:"longestDays"`  // 過去最長の連続活動日数
+	CurrentWeeks int `json:"currentWeeks"` // 今週まで続いている連続活動週数
+	LongestWeeks int `json:"longestWeeks"` // 過去最長の連続活動週数
+}
+
+// 保存済みの履歴と今週・先週のデータから継続記録を計算
+// history は過去の週のデータ（DailyCommits を含む）で、順序は問わない
+func CalculateStreaks(history []*WeeklyStats, comparison *WeeklyComparison) *Streaks {
+	current := comparison.CurrentWeek
+
+	// 日付ごとのコミット数（新しいデータで上書き）
+	days := make(map[string]int)
+	weeks := append(append([]*WeeklyStats{}, history...), comparison.PreviousWeek, current)
+	var first time.Time
+	for _, week := range weeks {
+		if week == nil {
+			continue
+		}
+		for _, day := range week.DailyCommits {
+			days[day.Date.Format("2006-01-02")] = day.Count
+			if first.IsZero() || day.Date.Before(first) {
+				first = day.Date
+			}
+		}
+	}
+
+	streaks := &Streaks{}
+	if first.IsZero() {
+		return streaks
+	}
+
+	// 日単位の連続記録（週の開始曜日に揃えて最古の週から走査する）
+	start := alignToWeekStart(first, current.StartDate)
+	run := 0
+	for d := start; !d.After(current.EndDate); d = d.AddDate(0, 0, 1) {
+		if days[d.Format("2006-01-02")] > 0 {
+			run++
+			streaks.LongestDays = max(streaks.LongestDays, run)
+		} else {
+			run = 0
+		}
+	}
+	streaks.CurrentDays = run
+
+	// 週単位の連続記録
+	run = 0
+	for w := start; !w.After(current.StartDate); w = w.AddDate(0, 0, 7) {
+		active := false
+		for i := 0; i < 7; i++ {
+			if days[w.AddDate(0, 0, i).Format("2006-01-02")] > 0 {
+				active = true
+				break
+			}
+		}
+		if active {
+			run++
+			streaks.LongestWeeks = max(streaks.LongestWeeks, run)
+		} else {
+			run = 0
+		}
+	}
+	streaks.CurrentWeeks = run
+
+	return streaks
+}
+
+// date を含む週の開始日（weekStart と同じ曜日）を返す
+func alignToWeekStart(date, weekStart time.Time) time.Time {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, weekStart.Location())
+	offset := (int(date.Weekday()) - int(weekStart.Weekday()) + 7) % 7
+	return date.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+// テスト用: 開始日から counts の順に日次データを持つ週を生成
+func newTestWeek(start time.Time, counts ...int) *WeeklyStats {
+	week := &WeeklyStats{StartDate: start, EndDate: start.AddDate(0, 0, 6)}
+	for i, count := range counts {
+		week.DailyCommits = append(week.DailyCommits, DailyCommit{Date: start.AddDate(0, 0, i), Count: count})
+	}
+	return week
+}
+
+// テスト: 継続記録の計算
+func TestCalculateStreaks(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	current := time.Date(2026, 2, 14, 0, 0, 0, 0, jst) // 土曜日
+
+	tests := []struct {
+		name     string
+		history  []*WeeklyStats
+		previous *WeeklyStats
+		current  *WeeklyStats
+		expected Streaks
+	}{
+		{
+			name:     "履歴なし・今週後半から連続",
+			previous: newTestWeek(current.AddDate(0, 0, -7), 0, 0, 0, 0, 0, 0, 0),
+			current:  newTestWeek(current, 1, 0, 0, 2, 3, 4, 5),
+			expected: Streaks{CurrentDays: 4, LongestDays: 4, CurrentWeeks: 1, LongestWeeks: 1},
+		},
+		{
+			name: "履歴を含めた最長記録と週の連続",
+			history: []*WeeklyStats{
+				newTestWeek(current.AddDate(0, 0, -21), 1, 1, 1, 1, 1, 1, 1),
+				newTestWeek(current.AddDate(0, 0, -14), 1, 1, 1, 0, 0, 0, 1),
+			},
+			previous: newTestWeek(current.AddDate(0, 0, -7), 1, 0, 0, 0, 0, 0, 0),
+			current:  newTestWeek(current, 0, 0, 0, 0, 0, 1, 1),
+			expected: Streaks{CurrentDays: 2, LongestDays: 10, CurrentWeeks: 4, LongestWeeks: 4},
+		},
+		{
+			name: "途中に活動のない週がある",
+			history: []*WeeklyStats{
+				newTestWeek(current.AddDate(0, 0, -21), 1, 0, 0, 0, 0, 0, 0),
+				newTestWeek(current.AddDate(0, 0, -14), 0, 0, 0, 0, 0, 0, 0),
+			},
+			previous: newTestWeek(current.AddDate(0, 0, -7), 0, 0, 1, 0, 0, 0, 0),
+			current:  newTestWeek(current, 0, 0, 0, 0, 0, 0, 0),
+			expected: Streaks{CurrentDays: 0, LongestDays: 1, CurrentWeeks: 0, LongestWeeks: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparison := &WeeklyComparison{CurrentWeek: tt.current, PreviousWeek: tt.previous}
+			got := CalculateStreaks(tt.history, comparison)
+			if *got != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, *got)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// メンバーごとの週間レポート
+type MemberReport struct {
+	Username   string            `json:"username"`   // GitHubユーザー名
+	Comparison *WeeklyComparison `json:"comparison"` // メンバー個人の前週比較データ
+}
+
+// チームのメンバー別サマリー
+type MemberSummary struct {
+	Username     string `json:"username"`     // GitHubユーザー名
+	TotalCommits int    `json:"totalCommits"` // 今週のコミット数
+	ActiveDays   int    `json:"activeDays"`   // 今週の活動日数
+	CommitsDiff  int    `json:"commitsDiff"`  // 前週比のコミット数差分
+}
+
+// チーム全体の集計データ
+type TeamStats struct {
+	TotalCommits      int             `json:"totalCommits"`      // チーム全体のコミット数
+	PreviousCommits   int             `json:"previousCommits"`   // チーム全体の先週のコミット数
+	CommitsDiff       int             `json:"commitsDiff"`       // コミット数の差分
+	CommitsChangeRate int             `json:"commitsChangeRate"` // コミット数の変化率（%）
+	ActiveMembers     int             `json:"activeMembers"`     // 今週コミットしたメンバー数
+	Members           []MemberSummary `json:"members"`           // メンバー別サマリー（コミット数の多い順）
+	RepoDetails       []RepoDetail    `json:"repoDetails"`       // 活動の多いリポジトリ（"owner/repo" 形式）
+	LanguageCommits   map[string]int  `json:"languageCommits"`   // 言語ごとのコミット数
+	MainLanguages     map[string]int  `json:"mainLanguages"`     // 主要言語ごとのコミット数
+	StartDate         time.Time       `json:"startDate"`         // 週間開始日
+	EndDate           time.Time       `json:"endDate"`           // 週間終了日
+}
+
+// チームレポート（メンバー個人のデータとチーム集計）
+type TeamReport struct {
+	Members []MemberReport `json:"members"` // メンバーごとのレポート
+	Team    *TeamStats     `json:"team"`    // チーム集計
+}
+
+// 複数メンバーのデータを取得し、チーム集計を行う
+func (c *Client) FetchTeamWeeklyCommits(ctx context.Context, usernames []string) (*TeamReport, error) {
+	if len(usernames) == 0 {
+		return nil, fmt.Errorf("team members are empty")
+	}
+
+	startDate, _ := getTargetRange()
+	report := &TeamReport{}
+	for _, username := range usernames {
+		fmt.Printf("Scanning %s\n", username)
+		// メンバーが所有するリポジトリに加え、組織・他のメンバーのリポジトリのコミットも数える
+		comparison, err := c.fetchWeeklyComparison(ctx, username, startDate, true)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching data for %s: %v", username, err)
+		}
+		report.Members = append(report.Members, MemberReport{
+			Username:   username,
+			Comparison: comparison,
+		})
+	}
+
+	report.Team = buildTeamStats(report.Members)
+	return report, nil
+}
+
+// メンバーごとのデータからチーム集計を生成
+func buildTeamStats(members []MemberReport) *TeamStats {
+	team := &TeamStats{
+		LanguageCommits: make(map[string]int),
+	}
+	repoCommits := make(map[string]int)
+
+	for _, member := range members {
+		current := member.Comparison.CurrentWeek
+		previous := member.Comparison.PreviousWeek
+
+		team.StartDate = current.StartDate
+		team.EndDate = current.EndDate
+		team.TotalCommits += current.TotalCommits
+		team.PreviousCommits += previous.TotalCommits
+		if current.TotalCommits > 0 {
+			team.ActiveMembers++
+		}
+
+		team.Members = append(team.Members, MemberSummary{
+			Username:     member.Username,
+			TotalCommits: current.TotalCommits,
+			ActiveDays:   current.ActiveDays,
+			CommitsDiff:  member.Comparison.CommitsDiff,
+		})
+
+		// 同名リポジトリが衝突しないよう "owner/repo" 形式で集計（共有リポジトリはメンバーのコミットを合算する）
+		for _, repo := range current.RepoDetails {
+			repoCommits[teamRepoName(member.Username, repo.Name)] += repo.Count
+		}
+		for lang, count := range current.LanguageCommits {
+			team.LanguageCommits[lang] += count
+		}
+	}
+
+	team.CommitsDiff = team.TotalCommits - team.PreviousCommits
+	if team.PreviousCommits > 0 {
+		rate := float64(team.CommitsDiff) / float64(team.PreviousCommits) * 100
+		team.CommitsChangeRate = int(math.Round(rate))
+	} else if team.TotalCommits > 0 {
+		team.CommitsChangeRate = 100 // 0から増加した場合は100%とする
+	}
+
+	// コミット数の多い順、同数ならユーザー名順
+	slices.SortFunc(team.Members, func(a, b MemberSummary) int {
+		if a.TotalCommits != b.TotalCommits {
+			return b.TotalCommits - a.TotalCommits
+		}
+		return strings.Compare(a.Username, b.Username)
+	})
+
+	team.MainLanguages = filterMainLanguages(team.LanguageCommits)
+	team.RepoDetails = generateRepoDetails(repoCommits)
+	if len(team.RepoDetails) > TEAM_TOP_REPOSITORIES {
+		team.RepoDetails = team.RepoDetails[:TEAM_TOP_REPOSITORIES]
+	}
+
+	return team
+}
+
+// チーム集計でのリポジトリ名（メンバー本人のリポジトリは "username/repo"、それ以外はすでに "owner/repo"）
+func teamRepoName(username, name string) string {
+	if strings.Contains(name, "/") {
+		return name
+	}
+	return username + "/" + name
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// テスト: チーム集計
+func TestBuildTeamStats(t *testing.T) {
+	members := []MemberReport{
+		{
+			Username: "alice",
+			Comparison: &WeeklyComparison{
+				CurrentWeek: &WeeklyStats{
+					TotalCommits: 10,
+					ActiveDays:   3,
+					RepoDetails: []RepoDetail{
+						{Name: "dotfiles", Count: 6},
+						{Name: "api", Count: 4},
+					},
+					LanguageCommits: map[string]int{"Go": 8, "Markdown": 2},
+				},
+				PreviousWeek: &WeeklyStats{TotalCommits: 5},
+				CommitsDiff:  5,
+			},
+		},
+		{
+			Username: "bob",
+			Comparison: &WeeklyComparison{
+				CurrentWeek: &WeeklyStats{
+					TotalCommits: 20,
+					ActiveDays:   5,
+					RepoDetails: []RepoDetail{
+						{Name: "dotfiles", Count: 20},
+					},
+					LanguageCommits: map[string]int{"Go": 1, "TypeScript": 7},
+				},
+				PreviousWeek: &WeeklyStats{TotalCommits: 15},
+				CommitsDiff:  5,
+			},
+		},
+		{
+			Username: "carol",
+			Comparison: &WeeklyComparison{
+				CurrentWeek:  &WeeklyStats{LanguageCommits: map[string]int{}},
+				PreviousWeek: &WeeklyStats{TotalCommits: 0},
+			},
+		},
+	}
+
+	team := buildTeamStats(members)
+
+	// 検証 1: 合計と変化率
+	if team.TotalCommits != 30 {
+		t.Errorf("TotalCommits: expected 30, got %d", team.TotalCommits)
+	}
+	if team.CommitsDiff != 10 {
+		t.Errorf("CommitsDiff: expected 10, got %d", team.CommitsDiff)
+	}
+	if team.CommitsChangeRate != 50 {
+		t.Errorf("CommitsChangeRate: expected 50, got %d", team.CommitsChangeRate)
+	}
+	if team.ActiveMembers != 2 {
+		t.Errorf("ActiveMembers: expected 2, got %d", team.ActiveMembers)
+	}
+
+	// 検証 2: メンバーはコミット数の多い順
+	expectedOrder := []string{"bob", "alice", "carol"}
+	for i, expected := range expectedOrder {
+		if team.Members[i].Username != expected {
+			t.Errorf("Member %d: expected %s, got %s", i, expected, team.Members[i].Username)
+		}
+	}
+
+	// 検証 3: 同名リポジトリはオーナーごとに区別される
+	if len(team.RepoDetails) != 3 {
+		t.Fatalf("RepoDetails: expected 3, got %d", len(team.RepoDetails))
+	}
+	if team.RepoDetails[0].Name != "bob/dotfiles" {
+		t.Errorf("Top repo: expected bob/dotfiles, got %s", team.RepoDetails[0].Name)
+	}
+
+	// 検証 4: 言語は合算され、主要言語のみ MainLanguages に入る
+	if team.LanguageCommits["Go"] != 9 {
+		t.Errorf("Go: expected 9, got %d", team.LanguageCommits["Go"])
+	}
+	if _, exists := team.MainLanguages["Markdown"]; exists {
+		t.Errorf("Markdown should not be a main language")
+	}
+}
+
+// テスト: チームモードでは組織・他のメンバーのリポジトリのコミットもメンバーごとに数える
+func TestFetchTeamWeeklyCommitsSharedRepos(t *testing.T) {
+	startDate, _ := getTargetRange()
+	commitDate := startDate.Add(12 * time.Hour).Format(time.RFC3339)
+	pushedAt := time.Now().Format(time.RFC3339)
+	repo := func(owner, name string) map[string]any {
+		return map[string]any{"name": name, "full_name": owner + "/" + name, "owner": map[string]any{"login": owner}, "pushed_at": pushedAt}
+	}
+	// リポジトリごとの作者とコミット数
+	commits := map[string]map[string]int{
+		"alice/api":   {"alice": 1},
+		"acme/shared": {"alice": 1, "bob": 2},
+		"bob/tool":    {"bob": 1, "alice": 1},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"login": "alice"})
+	})
+	mux.HandleFunc("/user/repos", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("affiliation") == "owner" {
+			json.NewEncoder(w).Encode([]map[string]any{repo("alice", "api")})
+			return
+		}
+		json.NewEncoder(w).Encode([]map[string]any{repo("acme", "shared"), repo("bob", "tool")})
+	})
+	mux.HandleFunc("/users/bob/repos", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]any{repo("bob", "tool")})
+	})
+	mux.HandleFunc("/repos/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/repos/"), "/")
+		switch {
+		case len(parts) == 3 && parts[2] == "commits":
+			author := r.URL.Query().Get("author")
+			var list []map[string]any
+			for i := range commits[parts[0]+"/"+parts[1]][author] {
+				list = append(list, map[string]any{
+					"sha":    fmt.Sprintf("%s-%s-%d", parts[1], author, i),
+					"author": map[string]any{"login": author, "type": "User"},
+					"commit": map[string]any{"message": "feat: work", "author": map[string]any{"name": author, "date": commitDate}},
+				})
+			}
+			json.NewEncoder(w).Encode(list)
+		case len(parts) == 4 && parts[2] == "commits":
+			json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{{"filename": "main.go", "additions": 1}}})
+		default:
+			fmt.Fprint(w, "[]")
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := NewClientWithOptions("test-token", Options{})
+	baseURL, _ := url.Parse(server.URL + "/")
+	client.ghClient.BaseURL = baseURL
+
+	report, err := client.FetchTeamWeeklyCommits(context.Background(), []string{"alice", "bob"})
+	if err != nil {
+		t.Fatalf("FetchTeamWeeklyCommits: %v", err)
+	}
+
+	alice, bob := report.Members[0].Comparison.CurrentWeek, report.Members[1].Comparison.CurrentWeek
+	if alice.TotalCommits != 3 || bob.TotalCommits != 3 {
+		t.Errorf("TotalCommits: expected 3 / 3, got %d / %d", alice.TotalCommits, bob.TotalCommits)
+	}
+	names := func(details []RepoDetail) map[string]int {
+		counts := make(map[string]int)
+		for _, detail := range details {
+			counts[detail.Name] = detail.Count
+		}
+		return counts
+	}
+	if got := names(alice.RepoDetails); got["api"] != 1 || got["acme/shared"] != 1 || got["bob/tool"] != 1 {
+		t.Errorf("alice repos: got %v", got)
+	}
+	if got := names(bob.RepoDetails); got["tool"] != 1 || got["acme/shared"] != 2 {
+		t.Errorf("bob repos: got %v", got)
+	}
+	// 共有リポジトリはメンバーのコミットを合算する
+	if got := names(report.Team.RepoDetails); report.Team.TotalCommits != 6 || got["acme/shared"] != 3 || got["bob/tool"] != 2 || got["alice/api"] != 1 {
+		t.Errorf("team: got %d commits, repos %v", report.Team.TotalCommits, got)
+	}
+}
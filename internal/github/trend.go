@@ -0,0 +1,202 @@
+package github
+
+import (
+	"math"
+	"slices"
+	"strings"
+)
+
+// トレンドの方向
+const (
+	TrendUp   = "up"   // 増加傾向
+	TrendDown = "down" // 減少傾向
+	TrendFlat = "flat" // 横ばい
+)
+
+// 指標ごとのトレンド
+type MetricTrend struct {
+	Current           int     `json:"current"`           // 今週の値
+	Average4Weeks     float64 `json:"average4Weeks"`     // 直近4週（今週を除く）の平均
+	Average12Weeks    float64 `json:"average12Weeks"`    // 直近12週（今週を除く）の平均
+	ChangeRate4Weeks  int     `json:"changeRate4Weeks"`  // 4週平均からの変化率（%）
+	ChangeRate12Weeks int     `json:"changeRate12Weeks"` // 12週平均からの変化率（%）
+	LastYear          int     `json:"lastYear"`          // 昨年の同じ週の値
+	HasLastYear       bool    `json:"hasLastYear"`       // 昨年の同じ週のデータがあるか
+	Direction         string  `json:"direction"`         // トレンドの方向（up / down / flat）
+}
+
+// リポジトリ・言語ごとのトレンド
+type ItemTrend struct {
+	Name          string  `json:"name"`          // リポジトリ名 / 言語名
+	Current       int     `json:"current"`       // 今週の値
+	Average4Weeks float64 `json:"average4Weeks"` // 直近4週（今週を除く）の平均
+	Direction     string  `json:"direction"`     // トレンドの方向（up / down / flat）
+}
+
+// 履歴を含めたトレンド比較
+type Trends struct {
+	Weeks      int         `json:"weeks"`      // 平均の計算に使用できた過去の週数（最大12）
+	Commits    MetricTrend `json:"commits"`    // コミット数のトレンド
+	ActiveDays MetricTrend `json:"activeDays"` // 活動日数のトレンド
+	Repos      []ItemTrend `json:"repos"`      // リポジトリ別のトレンド
+	Languages  []ItemTrend `json:"languages"`  // 主要言語別のトレンド
+}
+
+// 保存済みの履歴と今週・先週のデータからトレンドを計算
+// 過去の週が1つもない場合は nil を返す
+func CalculateTrends(history []*WeeklyStats, comparison *WeeklyComparison) *Trends {
+	current := comparison.CurrentWeek
+
+	// 開始日ごとに週をまとめる（先週は取得したばかりのデータを優先）
+	byStart := make(map[string]*WeeklyStats)
+	for _, week := range history {
+		byStart[week.StartDate.Format("2006-01-02")] = week
+	}
+	if comparison.PreviousWeek != nil {
+		byStart[comparison.PreviousWeek.StartDate.Format("2006-01-02")] = comparison.PreviousWeek
+	}
+
+	// 直近12週（新しい順）。保存されていない週はスキップする
+	var recent []*WeeklyStats
+	for i := 1; i <= TREND_LONG_WEEKS; i++ {
+		start := current.StartDate.AddDate(0, 0, -7*i)
+		if week, exists := byStart[start.Format("2006-01-02")]; exists {
+			recent = append(recent, week)
+		}
+	}
+	if len(recent) == 0 {
+		return nil
+	}
+	var short []*WeeklyStats
+	for _, week := range recent {
+		if !week.StartDate.Before(current.StartDate.AddDate(0, 0, -7*TREND_SHORT_WEEKS)) {
+			short = append(short, week)
+		}
+	}
+
+	trends := &Trends{Weeks: len(recent)}
+
+	lastYear := byStart[current.StartDate.AddDate(0, 0, -7*52).Format("2006-01-02")]
+
+	trends.Commits = newMetricTrend(current.TotalCommits, short, recent, lastYear, func(w *WeeklyStats) int { return w.TotalCommits })
+	trends.ActiveDays = newMetricTrend(current.ActiveDays, short, recent, lastYear, func(w *WeeklyStats) int { return w.ActiveDays })
+
+	// リポジトリ別（今週または直近4週に活動があったもの）
+	repoCounts := func(w *WeeklyStats) map[string]int {
+		counts := make(map[string]int)
+		for _, repo := range w.RepoDetails {
+			counts[repo.Name] = repo.Count
+		}
+		return counts
+	}
+	trends.Repos = newItemTrends(repoCounts(current), short, repoCounts)
+
+	// 主要言語別
+	langCounts := func(w *WeeklyStats) map[string]int {
+		return filterMainLanguages(w.LanguageCommits)
+	}
+	trends.Languages = newItemTrends(langCounts(current), short, langCounts)
+
+	return trends
+}
+
+// 指標のトレンドを計算
+func newMetricTrend(current int, short, long []*WeeklyStats, lastYear *WeeklyStats, value func(*WeeklyStats) int) MetricTrend {
+	trend := MetricTrend{
+		Current:        current,
+		Average4Weeks:  averageOf(short, value),
+		Average12Weeks: averageOf(long, value),
+	}
+	trend.ChangeRate4Weeks = changeRateFromAverage(current, trend.Average4Weeks)
+	trend.ChangeRate12Weeks = changeRateFromAverage(current, trend.Average12Weeks)
+	trend.Direction = classifyTrend(float64(current), trend.Average4Weeks)
+	if lastYear != nil {
+		trend.LastYear = value(lastYear)
+		trend.HasLastYear = true
+	}
+	return trend
+}
+
+// リポジトリ・言語ごとのトレンドを計算（今週の値の多い順）
+func newItemTrends(current map[string]int, short []*WeeklyStats, counts func(*WeeklyStats) map[string]int) []ItemTrend {
+	totals := make(map[string]int)
+	for _, week := range short {
+		for name, count := range counts(week) {
+			totals[name] += count
+		}
+	}
+	names := make(map[string]bool)
+	for name := range current {
+		names[name] = true
+	}
+	for name := range totals {
+		names[name] = true
+	}
+
+	var items []ItemTrend
+	for name := range names {
+		average := 0.0
+		if len(short) > 0 {
+			average = float64(totals[name]) / float64(len(short))
+		}
+		items = append(items, ItemTrend{
+			Name:          name,
+			Current:       current[name],
+			Average4Weeks: math.Round(average*10) / 10,
+			Direction:     classifyTrend(float64(current[name]), average),
+		})
+	}
+
+	slices.SortFunc(items, func(a, b ItemTrend) int {
+		if a.Current != b.Current {
+			return b.Current - a.Current
+		}
+		if a.Average4Weeks != b.Average4Weeks {
+			if a.Average4Weeks > b.Average4Weeks {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	if len(items) > TREND_TOP_ITEMS {
+		items = items[:TREND_TOP_ITEMS]
+	}
+	return items
+}
+
+// 平均値（小数第1位で丸める）
+func averageOf(weeks []*WeeklyStats, value func(*WeeklyStats) int) float64 {
+	if len(weeks) == 0 {
+		return 0
+	}
+	total := 0
+	for _, week := range weeks {
+		total += value(week)
+	}
+	return math.Round(float64(total)/float64(len(weeks))*10) / 10
+}
+
+// 平均からの変化率（%）
+func changeRateFromAverage(current int, average float64) int {
+	if average > 0 {
+		return int(math.Round((float64(current) - average) / average * 100))
+	}
+	if current > 0 {
+		return 100 // 0から増加した場合は100%とする
+	}
+	return 0
+}
+
+// 今週の値と平均を比較してトレンドの方向を判定
+func classifyTrend(current, average float64) string {
+	threshold := float64(TREND_THRESHOLD_PERCENT) / 100
+	switch {
+	case current > average*(1+threshold) && current-average >= 1:
+		return TrendUp
+	case current < average*(1-threshold) && average-current >= 1:
+		return TrendDown
+	default:
+		return TrendFlat
+	}
+}
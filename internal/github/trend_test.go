@@ -0,0 +1,114 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+// テスト: 4週・12週平均と昨年同週との比較
+func TestCalculateTrends(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	current := time.Date(2026, 2, 14, 0, 0, 0, 0, jst)
+
+	week := func(weeksAgo, commits int, repos map[string]int) *WeeklyStats {
+		stats := &WeeklyStats{
+			StartDate:       current.AddDate(0, 0, -7*weeksAgo),
+			TotalCommits:    commits,
+			ActiveDays:      commits / 10,
+			LanguageCommits: map[string]int{"Go": commits},
+		}
+		for name, count := range repos {
+			stats.RepoDetails = append(stats.RepoDetails, RepoDetail{Name: name, Count: count})
+		}
+		return stats
+	}
+
+	// 直近4週は各10、5〜12週前は各30、52週前は5
+	var history []*WeeklyStats
+	for i := 2; i <= 4; i++ {
+		history = append(history, week(i, 10, map[string]int{"api": 10}))
+	}
+	for i := 5; i <= 12; i++ {
+		history = append(history, week(i, 30, map[string]int{"legacy": 30}))
+	}
+	history = append(history, week(52, 5, nil))
+
+	comparison := &WeeklyComparison{
+		CurrentWeek:  week(0, 20, map[string]int{"api": 5, "new-tool": 15}),
+		PreviousWeek: week(1, 10, map[string]int{"api": 10}),
+	}
+
+	trends := CalculateTrends(history, comparison)
+	if trends == nil {
+		t.Fatal("trends should not be nil")
+	}
+
+	// 検証 1: コミット数の平均と変化率
+	commits := trends.Commits
+	if trends.Weeks != 12 {
+		t.Errorf("Weeks: expected 12, got %d", trends.Weeks)
+	}
+	if commits.Average4Weeks != 10 {
+		t.Errorf("Average4Weeks: expected 10, got %f", commits.Average4Weeks)
+	}
+	if commits.Average12Weeks != 23.3 {
+		t.Errorf("Average12Weeks: expected 23.3, got %f", commits.Average12Weeks)
+	}
+	if commits.ChangeRate4Weeks != 100 {
+		t.Errorf("ChangeRate4Weeks: expected 100, got %d", commits.ChangeRate4Weeks)
+	}
+	if commits.ChangeRate12Weeks != -14 {
+		t.Errorf("ChangeRate12Weeks: expected -14, got %d", commits.ChangeRate12Weeks)
+	}
+	if commits.Direction != TrendUp {
+		t.Errorf("Direction: expected up, got %s", commits.Direction)
+	}
+	if !commits.HasLastYear || commits.LastYear != 5 {
+		t.Errorf("LastYear: expected 5, got %d (has: %v)", commits.LastYear, commits.HasLastYear)
+	}
+
+	// 検証 2: リポジトリ別トレンド（直近4週に活動のない legacy は含まれない）
+	expected := map[string]string{"new-tool": TrendUp, "api": TrendDown}
+	if len(trends.Repos) != len(expected) {
+		t.Fatalf("Repos: expected %d, got %d (%+v)", len(expected), len(trends.Repos), trends.Repos)
+	}
+	if trends.Repos[0].Name != "new-tool" {
+		t.Errorf("Top repo: expected new-tool, got %s", trends.Repos[0].Name)
+	}
+	for _, repo := range trends.Repos {
+		if repo.Direction != expected[repo.Name] {
+			t.Errorf("Repo %s: expected %s, got %s", repo.Name, expected[repo.Name], repo.Direction)
+		}
+	}
+}
+
+// テスト: 履歴がない場合
+func TestCalculateTrendsWithoutHistory(t *testing.T) {
+	comparison := &WeeklyComparison{
+		CurrentWeek: &WeeklyStats{StartDate: time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)},
+	}
+	if trends := CalculateTrends(nil, comparison); trends != nil {
+		t.Errorf("expected nil, got %+v", trends)
+	}
+}
+
+// テスト: トレンド方向の判定
+func TestClassifyTrend(t *testing.T) {
+	tests := []struct {
+		current  float64
+		average  float64
+		expected string
+	}{
+		{current: 12, average: 10, expected: TrendFlat},
+		{current: 13, average: 10, expected: TrendUp},
+		{current: 7, average: 10, expected: TrendDown},
+		{current: 1, average: 0.5, expected: TrendFlat}, // 差が1未満は横ばい
+		{current: 0, average: 0, expected: TrendFlat},
+	}
+
+	for _, tt := range tests {
+		if got := classifyTrend(tt.current, tt.average); got != tt.expected {
+			t.Errorf("classifyTrend(%v, %v): expected %s, got %s", tt.current, tt.average, tt.expected, got)
+		}
+	}
+}
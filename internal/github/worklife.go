@@ -0,0 +1,207 @@
+package github
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 勤務時間・勤務日の設定
+type WorkSchedule struct {
+	StartHour      int     // 勤務開始時刻（この時刻を含む）
+	EndHour        int     // 勤務終了時刻（この時刻を含まない）
+	Days           [7]bool // time.Weekday ごとの勤務日
+	LateNightStart int     // 深夜帯の開始時刻（この時刻を含む）
+	LateNightEnd   int     // 深夜帯の終了時刻（この時刻を含まない・日付を跨いでよい）
+	Thresholds     WorkLifeThresholds
+}
+
+// 注意メッセージを表示する閾値
+type WorkLifeThresholds struct {
+	AfterHoursShare  int // 勤務時間外のコミットの割合（%）
+	WeekendShare     int // 休日のコミットの割合（%）
+	LateNightCommits int // 深夜帯のコミット数
+	Weeks            int // 何週連続で超えたら注意を表示するか（0の場合は表示しない）
+}
+
+// 1週間分のワークライフバランス指標
+type WorkLifeBalance struct {
+	AfterHoursCommits int `json:"afterHoursCommits"` // 勤務日の勤務時間外のコミット数
+	AfterHoursShare   int `json:"afterHoursShare"`   // 勤務時間外のコミットの割合（%）
+	WeekendCommits    int `json:"weekendCommits"`    // 休日のコミット数
+	WeekendShare      int `json:"weekendShare"`      // 休日のコミットの割合（%）
+	LateNightCommits  int `json:"lateNightCommits"`  // 深夜帯のコミット数
+}
+
+// 閾値を連続して超えた場合の注意
+type WorkLifeWarning struct {
+	ConsecutiveWeeks int  `json:"consecutiveWeeks"` // 閾値を超えた連続週数
+	AfterHours       bool `json:"afterHours"`       // 今週、勤務時間外の割合が閾値を超えた
+	Weekend          bool `json:"weekend"`          // 今週、休日の割合が閾値を超えた
+	LateNight        bool `json:"lateNight"`        // 今週、深夜帯のコミット数が閾値を超えた
+}
+
+// 既定の勤務スケジュール（平日9時〜18時、深夜帯は22時〜5時）
+func DefaultWorkSchedule() WorkSchedule {
+	return WorkSchedule{
+		StartHour:      9,
+		EndHour:        18,
+		Days:           [7]bool{false, true, true, true, true, true, false},
+		LateNightStart: 22,
+		LateNightEnd:   5,
+		Thresholds: WorkLifeThresholds{
+			AfterHoursShare:  30,
+			WeekendShare:     30,
+			LateNightCommits: 5,
+		},
+	}
+}
+
+// 環境変数の値から勤務スケジュールを生成する（空の項目は既定値）
+// hours: "9-18", days: "mon,tue,wed,thu,fri", lateNight: "22-5"
+func ParseWorkSchedule(hours, days, lateNight string) (WorkSchedule, error) {
+	schedule := DefaultWorkSchedule()
+
+	if hours != "" {
+		start, end, err := parseHourRange(hours)
+		if err != nil {
+			return schedule, fmt.Errorf("勤務時間の形式が不正です: %w", err)
+		}
+		schedule.StartHour, schedule.EndHour = start, end
+	}
+
+	if lateNight != "" {
+		start, end, err := parseHourRange(lateNight)
+		if err != nil {
+			return schedule, fmt.Errorf("深夜帯の形式が不正です: %w", err)
+		}
+		schedule.LateNightStart, schedule.LateNightEnd = start, end
+	}
+
+	if days != "" {
+		names := map[string]time.Weekday{
+			"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+			"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+		}
+		schedule.Days = [7]bool{}
+		for _, day := range strings.Split(days, ",") {
+			weekday, exists := names[strings.ToLower(strings.TrimSpace(day))]
+			if !exists {
+				return schedule, fmt.Errorf("勤務日の形式が不正です: %q", day)
+			}
+			schedule.Days[weekday] = true
+		}
+	}
+
+	return schedule, nil
+}
+
+// "9-18" 形式の時間帯を解析
+func parseHourRange(value string) (int, int, error) {
+	startStr, endStr, ok := strings.Cut(value, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("%q", value)
+	}
+	start, err := strconv.Atoi(strings.TrimSpace(startStr))
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := strconv.Atoi(strings.TrimSpace(endStr))
+	if err != nil {
+		return 0, 0, err
+	}
+	if start < 0 || start > 24 || end < 0 || end > 24 {
+		return 0, 0, fmt.Errorf("%q は 0-24 の範囲外です", value)
+	}
+	return start, end, nil
+}
+
+// 時刻が [start, end) に含まれるか（日付を跨ぐ範囲にも対応）
+func inHourRange(hour, start, end int) bool {
+	if start <= end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
+
+// 曜日×時間帯のコミット数からワークライフバランス指標を計算
+func CalculateWorkLife(heatmap *Heatmap, schedule WorkSchedule) WorkLifeBalance {
+	var balance WorkLifeBalance
+	total := 0
+
+	for weekday, hours := range heatmap {
+		for hour, count := range hours {
+			if count == 0 {
+				continue
+			}
+			total += count
+			if !schedule.Days[weekday] {
+				balance.WeekendCommits += count
+			} else if !inHourRange(hour, schedule.StartHour, schedule.EndHour) {
+				balance.AfterHoursCommits += count
+			}
+			if inHourRange(hour, schedule.LateNightStart, schedule.LateNightEnd) {
+				balance.LateNightCommits += count
+			}
+		}
+	}
+
+	if total > 0 {
+		balance.AfterHoursShare = int(math.Round(float64(balance.AfterHoursCommits) / float64(total) * 100))
+		balance.WeekendShare = int(math.Round(float64(balance.WeekendCommits) / float64(total) * 100))
+	}
+	return balance
+}
+
+// 閾値を超えた項目を判定
+func (t WorkLifeThresholds) exceeded(balance WorkLifeBalance) (afterHours, weekend, lateNight bool) {
+	afterHours = balance.AfterHoursShare >= t.AfterHoursShare && balance.AfterHoursCommits > 0
+	weekend = balance.WeekendShare >= t.WeekendShare && balance.WeekendCommits > 0
+	lateNight = balance.LateNightCommits >= t.LateNightCommits && balance.LateNightCommits > 0
+	return afterHours, weekend, lateNight
+}
+
+// 今週を含めて閾値を連続で超えている場合に注意を返す
+// 過去の週の指標は保存済みの曜日×時間帯のコミット数から計算する
+func EvaluateWorkLifeWarning(history []*WeeklyStats, comparison *WeeklyComparison, schedule WorkSchedule) *WorkLifeWarning {
+	thresholds := schedule.Thresholds
+	if thresholds.Weeks <= 0 {
+		return nil
+	}
+
+	current := comparison.CurrentWeek
+	afterHours, weekend, lateNight := thresholds.exceeded(CalculateWorkLife(&current.WeekdayHourlyActivity, schedule))
+	if !afterHours && !weekend && !lateNight {
+		return nil
+	}
+
+	// 開始日ごとに週をまとめる（先週は取得したばかりのデータを優先）
+	byStart := make(map[string]*WeeklyStats)
+	for _, week := range history {
+		byStart[week.StartDate.Format("2006-01-02")] = week
+	}
+	if comparison.PreviousWeek != nil {
+		byStart[comparison.PreviousWeek.StartDate.Format("2006-01-02")] = comparison.PreviousWeek
+	}
+
+	// 保存されていない週があれば連続はそこで途切れる
+	warning := &WorkLifeWarning{ConsecutiveWeeks: 1, AfterHours: afterHours, Weekend: weekend, LateNight: lateNight}
+	for i := 1; ; i++ {
+		week, exists := byStart[current.StartDate.AddDate(0, 0, -7*i).Format("2006-01-02")]
+		if !exists {
+			break
+		}
+		a, w, l := thresholds.exceeded(CalculateWorkLife(&week.WeekdayHourlyActivity, schedule))
+		if !a && !w && !l {
+			break
+		}
+		warning.ConsecutiveWeeks++
+	}
+
+	if warning.ConsecutiveWeeks < thresholds.Weeks {
+		return nil
+	}
+	return warning
+}
@@ -0,0 +1,131 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+// テスト: 勤務時間外・休日・深夜帯のコミット集計
+func TestCalculateWorkLife(t *testing.T) {
+	var heatmap Heatmap
+	heatmap[time.Monday][10] = 6    // 勤務時間内
+	heatmap[time.Tuesday][20] = 2   // 勤務時間外
+	heatmap[time.Wednesday][23] = 1 // 勤務時間外・深夜帯
+	heatmap[time.Saturday][2] = 1   // 休日・深夜帯
+
+	balance := CalculateWorkLife(&heatmap, DefaultWorkSchedule())
+
+	expected := WorkLifeBalance{
+		AfterHoursCommits: 3,
+		AfterHoursShare:   30,
+		WeekendCommits:    1,
+		WeekendShare:      10,
+		LateNightCommits:  2,
+	}
+	if balance != expected {
+		t.Errorf("expected %+v, got %+v", expected, balance)
+	}
+}
+
+// テスト: 勤務スケジュールの解析
+func TestParseWorkSchedule(t *testing.T) {
+	schedule, err := ParseWorkSchedule("10-19", "mon,tue,sat", "0-6")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schedule.StartHour != 10 || schedule.EndHour != 19 {
+		t.Errorf("hours: expected 10-19, got %d-%d", schedule.StartHour, schedule.EndHour)
+	}
+	if !schedule.Days[time.Saturday] || schedule.Days[time.Wednesday] {
+		t.Errorf("days: unexpected %v", schedule.Days)
+	}
+	if schedule.LateNightStart != 0 || schedule.LateNightEnd != 6 {
+		t.Errorf("late night: expected 0-6, got %d-%d", schedule.LateNightStart, schedule.LateNightEnd)
+	}
+
+	for _, input := range [][3]string{{"9", "", ""}, {"", "monday", ""}, {"", "", "22-25"}} {
+		if _, err := ParseWorkSchedule(input[0], input[1], input[2]); err == nil {
+			t.Errorf("ParseWorkSchedule(%q): expected error", input)
+		}
+	}
+}
+
+// テスト: 閾値を連続で超えた場合の注意
+func TestEvaluateWorkLifeWarning(t *testing.T) {
+	current := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+
+	// 休日のみコミットした週
+	weekendWeek := func(weeksAgo int) *WeeklyStats {
+		week := &WeeklyStats{StartDate: current.AddDate(0, 0, -7*weeksAgo)}
+		week.WeekdayHourlyActivity[time.Sunday][14] = 3
+		return week
+	}
+	// 勤務時間内のみコミットした週
+	normalWeek := func(weeksAgo int) *WeeklyStats {
+		week := &WeeklyStats{StartDate: current.AddDate(0, 0, -7*weeksAgo)}
+		week.WeekdayHourlyActivity[time.Monday][10] = 3
+		return week
+	}
+
+	schedule := DefaultWorkSchedule()
+	schedule.Thresholds.Weeks = 3
+
+	tests := []struct {
+		name         string
+		history      []*WeeklyStats
+		previous     *WeeklyStats
+		current      *WeeklyStats
+		expectedWeek int // 0 の場合は注意なし
+	}{
+		{
+			name:         "3週連続",
+			history:      []*WeeklyStats{weekendWeek(2), normalWeek(3)},
+			previous:     weekendWeek(1),
+			current:      weekendWeek(0),
+			expectedWeek: 3,
+		},
+		{
+			name:     "2週のみ",
+			history:  []*WeeklyStats{normalWeek(2)},
+			previous: weekendWeek(1),
+			current:  weekendWeek(0),
+		},
+		{
+			name:     "今週は超えていない",
+			history:  []*WeeklyStats{weekendWeek(2)},
+			previous: weekendWeek(1),
+			current:  normalWeek(0),
+		},
+		{
+			name:     "履歴が途切れている",
+			history:  []*WeeklyStats{weekendWeek(3), weekendWeek(4)},
+			previous: weekendWeek(1),
+			current:  weekendWeek(0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparison := &WeeklyComparison{CurrentWeek: tt.current, PreviousWeek: tt.previous}
+			warning := EvaluateWorkLifeWarning(tt.history, comparison, schedule)
+			if tt.expectedWeek == 0 {
+				if warning != nil {
+					t.Errorf("expected nil, got %+v", warning)
+				}
+				return
+			}
+			if warning == nil {
+				t.Fatal("expected warning, got nil")
+			}
+			if warning.ConsecutiveWeeks != tt.expectedWeek || !warning.Weekend || warning.AfterHours {
+				t.Errorf("unexpected warning: %+v", warning)
+			}
+		})
+	}
+
+	// Weeks が 0 の場合は注意を表示しない
+	comparison := &WeeklyComparison{CurrentWeek: weekendWeek(0), PreviousWeek: weekendWeek(1)}
+	if warning := EvaluateWorkLifeWarning([]*WeeklyStats{weekendWeek(2)}, comparison, DefaultWorkSchedule()); warning != nil {
+		t.Errorf("disabled: expected nil, got %+v", warning)
+	}
+}
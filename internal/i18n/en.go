@@ -0,0 +1,214 @@
+package i18n
+
+// 英語のメッセージカタログ
+var en = map[string]string{
+	// 曜日
+	"weekday.sun": "Sun",
+	"weekday.mon": "Mon",
+	"weekday.tue": "Tue",
+	"weekday.wed": "Wed",
+	"weekday.thu": "Thu",
+	"weekday.fri": "Fri",
+	"weekday.sat": "Sat",
+
+	// 目標の指標
+	"goal.commits":     "Commits",
+	"goal.active_days": "Active days",
+	"goal.merged_prs":  "Merged PRs",
+	"goal.repos":       "Repositories",
+	"goal.lines":       "Lines changed",
+
+	// ハイライトの種類
+	"highlight.release":      "Release",
+	"highlight.pull_request": "PR",
+	"highlight.commit":       "Commit",
+
+	// 主要言語の見出し（言語の指標ごと）
+	"languages.files":   "Files changed by language",
+	"languages.lines":   "Lines changed by language",
+	"languages.commits": "Commits by language",
+
+	// メール
+	"email.sender":         "Weekly Log",
+	"email.subject.weekly": "Weekly commit report (%s)",
+	"email.subject.team":   "Weekly team commit report (%s)",
+
+	// 週間レポート（HTML・テキスト共通）
+	"report.title":               "Weekly Commit Report",
+	"report.greeting":            "Great work this week, %s!",
+	"report.period":              "%s - %s",
+	"report.total_commits":       "Total commits",
+	"report.active_days":         "Active days",
+	"report.streaks":             "Streaks",
+	"report.streak_days":         "Daily streak",
+	"report.streak_weeks":        "Weekly streak",
+	"report.day_unit":            "days",
+	"report.week_unit":           "weeks",
+	"report.longest_days":        "(longest %d days)",
+	"report.longest_weeks":       "(longest %d weeks)",
+	"report.trends":              "Trends",
+	"report.average_4weeks":      "4-week average",
+	"report.average_12weeks":     "12-week average",
+	"report.last_year":           "Same week last year",
+	"report.no_last_year":        "No data for the same week last year",
+	"report.direction":           "Trend",
+	"report.compared_weeks":      "(compared with the last %d weeks)",
+	"report.trend_repos":         "Repositories",
+	"report.trend_languages":     "Languages",
+	"report.trend_average":       "(4-week average %.1f)",
+	"report.goals":               "Goals this week",
+	"report.goal_hit_rate":       "%s hit rate",
+	"report.goal_weeks":          "(%d/%d weeks)",
+	"report.worklife":            "Work-life balance",
+	"report.after_hours":         "After hours",
+	"report.weekend":             "Weekend",
+	"report.late_night":          "Late night",
+	"report.share":               "(%d%%)",
+	"report.commits":             "commits",
+	"report.worklife_warning":    "You have had a lot of %[2]s activity for %[1]d weeks in a row. Remember to take a break.",
+	"report.warning_after_hours": "after-hours",
+	"report.warning_weekend":     "weekend",
+	"report.warning_late_night":  "late-night",
+	"report.daily":               "Daily activity",
+	"report.charts":              "Charts",
+	"report.chart_daily":         "Commits per day",
+	"report.chart_hourly":        "Commits per hour",
+	"report.chart_hourly_note":   "Commits per hour (0:00 - 23:00)",
+	"report.chart_languages":     "Language share",
+	"report.chart_repos":         "Commits per repository",
+	"report.repos":               "Repository activity",
+	"report.shipped":             "Shipped this week",
+	"report.highlights":          "Highlights",
+	"report.hotspots":            "Frequently changed files",
+	"report.heatmap":             "Activity by weekday and hour",
+	"report.heatmap_cell":        "%d:00: %d",
+	"report.long_term":           "Last %d weeks",
+	"report.days_of_week":        "/ 7 days",
+	"report.private":             "Private",
+	"report.hotspot_changes":     "%d commits · %d lines",
+	"report.lines_changed":       "lines changed",
+	"report.files_changed":       "files changed",
+	"report.footer":              "Sent by GitHub Weekly Log System",
+
+	// チームダイジェスト
+	"team.title":          "Weekly Team Commit Report",
+	"team.total_commits":  "Team commits",
+	"team.active_members": "Active members",
+	"team.members":        "Activity by member",
+	"team.repos":          "Most active repositories",
+	"team.languages":      "Team languages",
+	"team.member_stats":   "%d commits / %d days",
+
+	// テキスト版
+	"text.period":         "Period: %s - %s",
+	"text.total_commits":  "Total commits: %d (last week %d, %s)",
+	"text.active_days":    "Active days: %d / 7",
+	"text.streak_days":    "Daily streak: %d days (longest %d days)",
+	"text.streak_weeks":   "Weekly streak: %d weeks (longest %d weeks)",
+	"text.daily":          "Commits per day",
+	"text.date":           "Date",
+	"text.weekday":        "Day",
+	"text.commits":        "Commits",
+	"text.goal":           "Goal",
+	"text.status":         "Status",
+	"text.actual":         "Actual",
+	"text.hit_rate":       "Hit rate",
+	"text.achieved":       "Achieved",
+	"text.not_achieved":   "Missed",
+	"text.goal_hit_rate":  "%d%% (%d/%d weeks)",
+	"text.repos":          "Commits per repository",
+	"text.repo":           "Repository",
+	"text.language":       "Language",
+	"text.this_week":      "This week",
+	"text.last_week":      "Last week",
+	"text.diff":           "Diff",
+	"text.increase":       "%+d, up %d%%",
+	"text.decrease":       "%d, down %d%%",
+	"text.no_change":      "no change",
+	"text.active_members": "Active members: %d / %d",
+	"text.members":        "Members",
+	"text.member":         "Member",
+	"text.member_days":    "Active days",
+	"text.member_diff":    "vs last week",
+
+	// Markdown
+	"markdown.title":          "# Weekly commit report (%s - %s)",
+	"markdown.total_commits":  "- Total commits: %d (%+d vs last week)",
+	"markdown.active_days":    "- Active days: %d / 7",
+	"markdown.goal_hit_rate":  "- %s hit rate: %d%% (%d/%d weeks)",
+	"markdown.team_title":     "# Weekly team commit report (%s - %s)",
+	"markdown.active_members": "- Active members: %d / %d",
+
+	// コンソール出力
+	"console.development":      "Running in development. Data is saved to the development DB.",
+	"console.production":       "Running in production. Data is saved to the production DB.",
+	"console.email_only":       "email-only mode: skipping the DB save.",
+	"console.history_failed":   "Failed to load history: %v",
+	"console.comparison":       "Weekly commit report (vs last week)",
+	"console.this_week":        "📅 This week: %s - %s",
+	"console.last_week":        "📅 Last week: %s - %s",
+	"console.total_commits":    "📊 Total commits:",
+	"console.commits_current":  "  This week: %d",
+	"console.commits_previous": "  Last week: %d",
+	"console.increase":         "  📈 %+d (up %d%%)",
+	"console.decrease":         "  📉 %d (down %d%%)",
+	"console.no_change":        "  ➡️  No change",
+	"console.streaks":          "🔥 Streaks:",
+	"console.streak_days":      "  Daily streak: %d days (longest %d days)",
+	"console.streak_weeks":     "  Weekly streak: %d weeks (longest %d weeks)",
+	"console.goals":            "🎯 Goals:",
+	"console.goal":             "  %s %s: %d / %d (%d%%)  hit rate %d%% (%d/%d weeks)",
+	"console.excluded":         "🙈 Excluded commits:",
+	"console.excluded_total":   "  Total: %d (bot: %d, message: %d, path: %d)",
+	"console.ignored_files":    "  Files excluded from languages: %d",
+	"console.commit_types":     "🏷️  Commit types:",
+	"console.worklife":         "🌙 Commits outside working hours:",
+	"console.after_hours":      "  After hours: %d (%d%%)",
+	"console.weekend":          "  Weekend: %d (%d%%)",
+	"console.late_night":       "  Late night: %d",
+	"console.worklife_warning": "  ⚠️  Over the threshold for %d weeks in a row",
+	"console.trends":           "📈 Trends:",
+	"console.average_4weeks":   "  4-week average:  %.1f (%+d%%)",
+	"console.average_12weeks":  "  12-week average: %.1f (%+d%%)",
+	"console.last_year":        "  Same week last year: %d",
+	"console.direction":        "  Trend: %s",
+	"console.repos":            "📁 Commits per repository:",
+	"console.repos_header":     "  Repository          This  Last  Diff",
+	"console.languages":        "💻 Files changed per language:",
+	"console.languages_header": "  Language            This  Last  Diff",
+	"console.member_skipped":   "Skipping %s: no email address configured",
+	"console.team_skipped":     "Skipping the team digest: TEAM_LEAD_EMAIL is not set",
+	"console.repos_included":   "📁 Included:",
+	"console.repos_excluded":   "🚫 Excluded:",
+	"console.repo":             "Repository",
+	"console.rule":             "Rule",
+	"console.repos_total":      "%d in total (%d included / %d excluded)",
+	"console.preview_url":      "Preview: http://%s/",
+	"console.preview_watching": "Watching %s for changes",
+	"console.preview_changed":  "Templates changed",
+	"console.send_retry":       "  ↻ Send failed (attempt %d/%d, retrying in %s): %v",
+	"console.smtp_quit_failed": "  ⚠️  QUIT failed after the message was accepted (the message was sent): %v",
+	"console.delivery_skipped": "  ⏭️  Skipping %s: already delivered",
+	"console.week_delivered":   "The week of %s - %s has already been delivered",
+	"console.no_pending_weeks": "All of the last %d stored weeks have been delivered",
+	"console.record_failed":    "  ⚠️  Failed to record the delivery (running again may deliver it twice): %v",
+	"console.send_hint":        "The stats are saved to D1. Run `fetcher send` to deliver to the remaining recipients",
+	"console.team_send_hint":   "The stats are saved to D1. Run again within the same week to deliver to the remaining recipients only",
+
+	// D1 への保存のログ
+	"log.save_start":       "[INFO] Saving weekly stats",
+	"log.stats_insert":     "[INFO] Inserting into weekly_stats",
+	"log.stats_failed":     "[ERROR] Failed to insert weekly_stats: %v",
+	"log.stats_inserted":   "[INFO] Inserted weekly_stats (ID: %s)",
+	"log.children_delete":  "[INFO] Deleting existing child rows",
+	"log.delete_failed":    "[WARN] Failed to delete existing rows: %v",
+	"log.children_insert":  "[INFO] Inserting child rows",
+	"log.children_failed":  "[ERROR] Failed to insert child rows: %v",
+	"log.save_done":        "[INFO] Saved weekly stats (ID: %s, user: %s, commits: %d, active_days: %d)",
+	"log.children_deleted": "[INFO] Deleted existing child rows (%d)",
+	"log.no_children":      "[WARN] No child rows to insert",
+	"log.batch":            "[INFO] Running batch (daily: %d, hourly: %d, heatmap: %d, repos: %d, langs: %d, types: %d, scopes: %d, goals: %d, total: %d)",
+	"log.batch_failed":     "[ERROR] Batch #%d failed",
+	"log.children_done":    "[INFO] Inserted child rows (%d)",
+	"log.history":          "[INFO] Loaded history (weeks: %d, days: %d, repos: %d, langs: %d, heatmap: %d, goals: %d)",
+}
@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+// 出力の言語
+type Language string
+
+const (
+	Japanese Language = "ja"
+	English  Language = "en"
+)
+
+// 言語を指定しない場合の言語
+const Default = Japanese
+
+// 対応している言語
+var Languages = []Language{Japanese, English}
+
+// 言語ごとのメッセージカタログ（キー → fmt の書式）
+var catalogs = map[Language]map[string]string{
+	Japanese: ja,
+	English:  en,
+}
+
+// 言語の指定を解析（空の場合は既定の言語）
+func Parse(value string) (Language, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return Default, nil
+	}
+	lang := Language(value)
+	if !slices.Contains(Languages, lang) {
+		names := make([]string, len(Languages))
+		for i, l := range Languages {
+			names[i] = string(l)
+		}
+		return "", fmt.Errorf("未対応の言語です: %q（%s）", value, strings.Join(names, ", "))
+	}
+	return lang, nil
+}
+
+// 未指定（空）の場合は既定の言語を返す
+func (l Language) OrDefault() Language {
+	if l == "" {
+		return Default
+	}
+	return l
+}
+
+// メッセージを取得し、引数がある場合は書式に埋め込む
+// カタログにないキーは既定の言語、それもなければキーをそのまま返す
+func (l Language) T(key string, args ...any) string {
+	format, exists := catalogs[l.OrDefault()][key]
+	if !exists {
+		if format, exists = catalogs[Default][key]; !exists {
+			format = key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// 種類ごとの表示名（"goal" と "commits" なら goal.commits、カタログにない場合は name をそのまま返す）
+func (l Language) Label(kind, name string) string {
+	key := kind + "." + name
+	if _, exists := catalogs[Default][key]; !exists {
+		return name
+	}
+	return l.T(key)
+}
+
+// 曜日（"月" / "Mon"）
+func (l Language) Weekday(day time.Weekday) string {
+	return l.T("weekday." + strings.ToLower(day.String()[:3]))
+}
+
+// 月日（"1月2日" / "Jan 2"）
+func (l Language) Date(t time.Time) string {
+	if l.OrDefault() == English {
+		return t.Format("Jan 2")
+	}
+	return t.Format("1月2日")
+}
+
+// 年月日（"2006/01/02" / "Jan 2, 2006"）
+func (l Language) FullDate(t time.Time) string {
+	if l.OrDefault() == English {
+		return t.Format("Jan 2, 2006")
+	}
+	return t.Format("2006/01/02")
+}
+
+// コンソール出力の言語
+var console = Default
+
+// コンソール出力の言語を設定
+func SetConsole(lang Language) {
+	console = lang.OrDefault()
+}
+
+// コンソール出力の言語
+func Console() Language {
+	return console
+}
+
+// コンソールの言語でメッセージを出力
+func Println(key string, args ...any) {
+	fmt.Println(console.T(key, args...))
+}
+
+// コンソールの言語でログを出力
+func Logf(key string, args ...any) {
+	log.Print(console.T(key, args...))
+}
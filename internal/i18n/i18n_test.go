@@ -0,0 +1,92 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 書式の動詞（%d, %[2]s など、%% は除く）
+var verbPattern = regexp.MustCompile(`%(\[\d+\])?[-+# 0-9.]*[a-zA-Z]`)
+
+// 書式の動詞を並び順によらず比較できるように変換
+func formatVerbs(format string) []string {
+	verbs := verbPattern.FindAllString(strings.ReplaceAll(format, "%%", ""), -1)
+	for i, verb := range verbs {
+		// %[2]s のような引数の指定と、幅・符号などは除いて型の文字だけを比較する
+		verbs[i] = verb[len(verb)-1:]
+	}
+	slices.Sort(verbs)
+	return verbs
+}
+
+// テスト: すべての言語のカタログが同じキーと書式の動詞を持つ
+func TestCatalogs(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key, format := range catalogs[Default] {
+			translated, exists := catalog[key]
+			if !exists {
+				t.Errorf("%s: missing key %q", lang, key)
+				continue
+			}
+			if got, want := formatVerbs(translated), formatVerbs(format); !slices.Equal(got, want) {
+				t.Errorf("%s: %q has verbs %v, want %v", lang, key, got, want)
+			}
+		}
+		for key := range catalog {
+			if _, exists := catalogs[Default][key]; !exists {
+				t.Errorf("%s: unknown key %q", lang, key)
+			}
+		}
+	}
+}
+
+// テスト: メッセージと日付・曜日の表示
+func TestLanguage(t *testing.T) {
+	date := time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		lang                                  Language
+		subject, weekday, shortDate, fullDate string
+	}{
+		{Japanese, "週間コミットレポート (x)", "月", "2月16日", "2026/02/16"},
+		{English, "Weekly commit report (x)", "Mon", "Feb 16", "Feb 16, 2026"},
+		{"", "週間コミットレポート (x)", "月", "2月16日", "2026/02/16"},
+	} {
+		if got := tt.lang.T("email.subject.weekly", "x"); got != tt.subject {
+			t.Errorf("%q T: got %q", tt.lang, got)
+		}
+		if got := tt.lang.Weekday(date.Weekday()); got != tt.weekday {
+			t.Errorf("%q Weekday: got %q", tt.lang, got)
+		}
+		if got := tt.lang.Date(date); got != tt.shortDate {
+			t.Errorf("%q Date: got %q", tt.lang, got)
+		}
+		if got := tt.lang.FullDate(date); got != tt.fullDate {
+			t.Errorf("%q FullDate: got %q", tt.lang, got)
+		}
+	}
+
+	if got := English.T("report.worklife_warning", 3, English.T("report.warning_weekend")); !strings.HasPrefix(got, "You have had a lot of weekend activity for 3 weeks") {
+		t.Errorf("indexed verbs: got %q", got)
+	}
+	if got := English.T("unknown.key"); got != "unknown.key" {
+		t.Errorf("unknown key: got %q", got)
+	}
+	if English.Label("goal", "active_days") != "Active days" || English.Label("goal", "custom") != "custom" {
+		t.Errorf("Label: unexpected result")
+	}
+}
+
+// テスト: 言語の指定の解析
+func TestParse(t *testing.T) {
+	for value, want := range map[string]Language{"": Japanese, "ja": Japanese, " EN ": English} {
+		if got, err := Parse(value); err != nil || got != want {
+			t.Errorf("Parse(%q): got %q (%v)", value, got, err)
+		}
+	}
+	if _, err := Parse("fr"); err == nil || !strings.Contains(err.Error(), "ja, en") {
+		t.Errorf("expected error for unsupported language, got %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package i18n
+
+// 日本語のメッセージカタログ
+var ja = map[string]string{
+	// 曜日
+	"weekday.sun": "日",
+	"weekday.mon": "月",
+	"weekday.tue": "火",
+	"weekday.wed": "水",
+	"weekday.thu": "木",
+	"weekday.fri": "金",
+	"weekday.sat": "土",
+
+	// 目標の指標
+	"goal.commits":     "コミット数",
+	"goal.active_days": "活動日数",
+	"goal.merged_prs":  "マージされたPR",
+	"goal.repos":       "リポジトリ数",
+	"goal.lines":       "変更行数",
+
+	// ハイライトの種類
+	"highlight.release":      "リリース",
+	"highlight.pull_request": "PR",
+	"highlight.commit":       "コミット",
+
+	// 主要言語の見出し（言語の指標ごと）
+	"languages.files":   "主要言語の変更ファイル数",
+	"languages.lines":   "主要言語の変更行数",
+	"languages.commits": "主要言語のコミット数",
+
+	// メール
+	"email.sender":         "お疲れ様委員会",
+	"email.subject.weekly": "週間コミットレポート (%s)",
+	"email.subject.team":   "チーム週間コミットレポート (%s)",
+
+	// 週間レポート（HTML・テキスト共通）
+	"report.title":               "週間コミットレポート",
+	"report.greeting":            "%s さん、今週もお疲れ様でした",
+	"report.period":              "%s 〜 %s",
+	"report.total_commits":       "トータルコミット",
+	"report.active_days":         "活動日数",
+	"report.streaks":             "継続記録",
+	"report.streak_days":         "連続活動日数",
+	"report.streak_weeks":        "連続活動週数",
+	"report.day_unit":            "日",
+	"report.week_unit":           "週",
+	"report.longest_days":        "（最長 %d 日）",
+	"report.longest_weeks":       "（最長 %d 週）",
+	"report.trends":              "トレンド",
+	"report.average_4weeks":      "4週平均",
+	"report.average_12weeks":     "12週平均",
+	"report.last_year":           "昨年同週",
+	"report.no_last_year":        "昨年同週のデータはありません",
+	"report.direction":           "傾向",
+	"report.compared_weeks":      "（過去%d週のデータと比較）",
+	"report.trend_repos":         "リポジトリ",
+	"report.trend_languages":     "主要言語",
+	"report.trend_average":       "(4週平均 %.1f)",
+	"report.goals":               "今週の目標",
+	"report.goal_hit_rate":       "%sの達成率",
+	"report.goal_weeks":          "（%d/%d週）",
+	"report.worklife":            "ワークライフバランス",
+	"report.after_hours":         "勤務時間外",
+	"report.weekend":             "休日",
+	"report.late_night":          "深夜帯",
+	"report.share":               "（%d%%）",
+	"report.commits":             "コミット",
+	"report.worklife_warning":    "%d週連続で%sの活動が多くなっています。無理せず休息も取ってくださいね。",
+	"report.warning_after_hours": "勤務時間外",
+	"report.warning_weekend":     "休日",
+	"report.warning_late_night":  "深夜帯",
+	"report.daily":               "頑張りゲージ",
+	"report.charts":              "グラフ",
+	"report.chart_daily":         "日別のコミット数",
+	"report.chart_hourly":        "時間帯ごとのコミット数",
+	"report.chart_hourly_note":   "時間帯ごとのコミット数（0時〜23時）",
+	"report.chart_languages":     "主要言語の割合",
+	"report.chart_repos":         "リポジトリ別のコミット数",
+	"report.repos":               "リポジトリ別活動",
+	"report.shipped":             "今週の成果",
+	"report.highlights":          "ハイライト",
+	"report.hotspots":            "よく変更したファイル",
+	"report.heatmap":             "曜日×時間帯の活動",
+	"report.heatmap_cell":        "%d時: %d",
+	"report.long_term":           "直近%d週間の傾向",
+	"report.days_of_week":        "/ 7日",
+	"report.private":             "非公開",
+	"report.hotspot_changes":     "%d コミット · %d 行",
+	"report.lines_changed":       "行の変更",
+	"report.files_changed":       "ファイルの変更",
+	"report.footer":              "GitHub Weekly Log System から送信",
+
+	// チームダイジェスト
+	"team.title":          "チーム週間コミットレポート",
+	"team.total_commits":  "チーム合計コミット",
+	"team.active_members": "活動メンバー",
+	"team.members":        "メンバー別活動",
+	"team.repos":          "活動の多いリポジトリ",
+	"team.languages":      "チームの主要言語",
+	"team.member_stats":   "%d コミット / %d 日",
+
+	// テキスト版
+	"text.period":         "期間: %s 〜 %s",
+	"text.total_commits":  "総コミット数: %d（先週 %d、%s）",
+	"text.active_days":    "活動日数: %d / 7",
+	"text.streak_days":    "連続活動日数: %d日（最長 %d日）",
+	"text.streak_weeks":   "連続活動週数: %d週（最長 %d週）",
+	"text.daily":          "日別のコミット数",
+	"text.date":           "日付",
+	"text.weekday":        "曜日",
+	"text.commits":        "コミット",
+	"text.goal":           "目標",
+	"text.status":         "状況",
+	"text.actual":         "実績",
+	"text.hit_rate":       "達成率",
+	"text.achieved":       "達成",
+	"text.not_achieved":   "未達",
+	"text.goal_hit_rate":  "%d%%（%d/%d週）",
+	"text.repos":          "リポジトリ別コミット数",
+	"text.repo":           "リポジトリ",
+	"text.language":       "言語",
+	"text.this_week":      "今週",
+	"text.last_week":      "先週",
+	"text.diff":           "差分",
+	"text.increase":       "%+d、%d%% 増加",
+	"text.decrease":       "%d、%d%% 減少",
+	"text.no_change":      "変化なし",
+	"text.active_members": "活動メンバー: %d / %d",
+	"text.members":        "メンバー別",
+	"text.member":         "メンバー",
+	"text.member_days":    "活動日数",
+	"text.member_diff":    "前週比",
+
+	// Markdown
+	"markdown.title":          "# 週間コミットレポート (%s 〜 %s)",
+	"markdown.total_commits":  "- 総コミット数: %d（先週比 %+d）",
+	"markdown.active_days":    "- 活動日数: %d / 7",
+	"markdown.goal_hit_rate":  "- %sの達成率: %d%%（%d/%d週）",
+	"markdown.team_title":     "# チーム週間コミットレポート (%s 〜 %s)",
+	"markdown.active_members": "- 活動メンバー: %d / %d",
+
+	// コンソール出力
+	"console.development":      "開発環境で実行中です。開発DBに保存されます。",
+	"console.production":       "本番環境で実行中です。本番DBに保存されます。",
+	"console.email_only":       "email-only モード: DB保存をスキップします。",
+	"console.history_failed":   "履歴の取得に失敗しました: %v",
+	"console.comparison":       "週間コミットレポート（前週比）",
+	"console.this_week":        "📅 今週: %s 〜 %s",
+	"console.last_week":        "📅 先週: %s 〜 %s",
+	"console.total_commits":    "📊 総コミット数:",
+	"console.commits_current":  "  今週: %d",
+	"console.commits_previous": "  先週: %d",
+	"console.increase":         "  📈 %+d (%d%% 増加)",
+	"console.decrease":         "  📉 %d (%d%% 減少)",
+	"console.no_change":        "  ➡️  変化なし",
+	"console.streaks":          "🔥 継続記録:",
+	"console.streak_days":      "  連続活動日数: %d日（最長 %d日）",
+	"console.streak_weeks":     "  連続活動週数: %d週（最長 %d週）",
+	"console.goals":            "🎯 目標:",
+	"console.goal":             "  %s %s: %d / %d (%d%%)  達成率 %d%%（%d/%d週）",
+	"console.excluded":         "🙈 除外したコミット:",
+	"console.excluded_total":   "  合計: %d（bot: %d, メッセージ: %d, パス: %d）",
+	"console.ignored_files":    "  言語集計から除外したファイル: %d",
+	"console.commit_types":     "🏷️  コミットの種類:",
+	"console.worklife":         "🌙 勤務時間外のコミット:",
+	"console.after_hours":      "  勤務時間外: %d (%d%%)",
+	"console.weekend":          "  休日: %d (%d%%)",
+	"console.late_night":       "  深夜帯: %d",
+	"console.worklife_warning": "  ⚠️  %d週連続で閾値を超えています",
+	"console.trends":           "📈 トレンド:",
+	"console.average_4weeks":   "  4週平均:  %.1f (%+d%%)",
+	"console.average_12weeks":  "  12週平均: %.1f (%+d%%)",
+	"console.last_year":        "  昨年同週: %d",
+	"console.direction":        "  傾向: %s",
+	"console.repos":            "📁 リポジトリ別コミット数:",
+	"console.repos_header":     "  リポジトリ名          今週  先週  差分",
+	"console.languages":        "💻 言語別変更ファイル数:",
+	"console.languages_header": "  言語                  今週  先週  差分",
+	"console.member_skipped":   "%s の送信先が未設定のためスキップします",
+	"console.team_skipped":     "TEAM_LEAD_EMAIL が未設定のためチームダイジェストをスキップします",
+	"console.repos_included":   "📁 集計対象:",
+	"console.repos_excluded":   "🚫 除外:",
+	"console.repo":             "リポジトリ",
+	"console.rule":             "ルール",
+	"console.repos_total":      "合計 %d 件（対象 %d 件 / 除外 %d 件）",
+	"console.preview_url":      "プレビュー: http://%s/",
+	"console.preview_watching": "%s の変更を監視しています",
+	"console.preview_changed":  "テンプレートが変更されました",
+	"console.send_retry":       "  ↻ 送信に失敗しました（%d/%d 回目、%s 後に再試行します）: %v",
+	"console.smtp_quit_failed": "  ⚠️  送信後の QUIT に失敗しました（送信は完了しています）: %v",
+	"console.delivery_skipped": "  ⏭️  %s は送信済みのためスキップします",
+	"console.week_delivered":   "%s 〜 %s の週は送信済みです",
+	"console.no_pending_weeks": "保存済みの直近 %d 週はすべて送信済みです",
+	"console.record_failed":    "  ⚠️  送信済みの記録に失敗しました（再実行すると二重に届く可能性があります）: %v",
+	"console.send_hint":        "D1 への保存は完了しています。未送信の送信先には `fetcher send` で再送できます",
+	"console.team_send_hint":   "D1 への保存は完了しています。同じ週のうちに再実行すると、未送信の送信先にだけ送信します",
+
+	// D1 への保存のログ
+	"log.save_start":       "[INFO] データ保存処理を開始します",
+	"log.stats_insert":     "[INFO] weekly_stats テーブルへの挿入を開始",
+	"log.stats_failed":     "[ERROR] weekly_stats の挿入に失敗しました: %v",
+	"log.stats_inserted":   "[INFO] weekly_stats を挿入しました (ID: %s)",
+	"log.children_delete":  "[INFO] 既存の子データを削除中",
+	"log.delete_failed":    "[WARN] 既存データの削除に失敗しました: %v",
+	"log.children_insert":  "[INFO] 子データの挿入を開始",
+	"log.children_failed":  "[ERROR] 子データの挿入に失敗しました: %v",
+	"log.save_done":        "[INFO] データ保存が完了しました (ID: %s, user: %s, commits: %d, active_days: %d)",
+	"log.children_deleted": "[INFO] 既存の子データを削除しました (%d件)",
+	"log.no_children":      "[WARN] 挿入する子データがありません",
+	"log.batch":            "[INFO] バッチ処理を実行します (daily: %d, hourly: %d, heatmap: %d, repos: %d, langs: %d, types: %d, scopes: %d, goals: %d, total: %d)",
+	"log.batch_failed":     "[ERROR] バッチ処理 #%d が失敗しました",
+	"log.children_done":    "[INFO] 子データの挿入が完了しました (%d件)",
+	"log.history":          "[INFO] 履歴データを取得しました (weeks: %d, days: %d, repos: %d, langs: %d, heatmap: %d, goals: %d)",
+}
@@ -0,0 +1,9 @@
+// コンパイル済みのメールテンプレート（templates/dist）をバイナリに埋め込む
+package templates
+
+import "embed"
+
+// templates/dist 以下の HTML（パスは "dist/weekly.html" の形式）
+//
+//go:embed dist/*.html
+var Dist embed.FS
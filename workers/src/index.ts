@@ -97,11 +97,20 @@ const d1Query = async (c: Context<Env>, sql: string) => {
 
 app.get('/', (c) => c.text('OK'))
 
+// GitHub のユーザー名として有効な文字列のみ許可する
+const GITHUB_USER_PATTERN = /^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$/
+
 app.get('/api/weekly', async (c) => {
+  const user = c.req.query('user')
+  if (user !== undefined && !GITHUB_USER_PATTERN.test(user)) {
+    return c.json({ error: 'Invalid user' }, 400)
+  }
+
   try {
+    const where = user ? ` WHERE username = '${user}'` : ''
     const rows = await d1Query(
       c,
-      'SELECT id, start_date, end_date, total_commits, active_days, created_at FROM weekly_stats ORDER BY start_date DESC'
+      `SELECT id, username, start_date, end_date, total_commits, active_days, created_at FROM weekly_stats${where} ORDER BY start_date DESC`
     )
     return c.json({ items: rows })
   } catch (error) {
@@ -136,15 +145,47 @@ app.get('/api/weekly/:id', async (c) => {
       `SELECT hour, commits FROM hourly_activity WHERE weekly_stats_id = ${id} ORDER BY hour ASC`
     )) as Array<{ hour: number; commits: number }>
 
+    const heatmapRows = (await d1Query(
+      c,
+      `SELECT weekday, hour, commits FROM weekday_hourly_activity WHERE weekly_stats_id = ${id}`
+    )) as Array<{ weekday: number; hour: number; commits: number }>
+
+    // [曜日(0=日曜日)][時] の 7x24 行列に展開
+    const heatmap = Array.from({ length: 7 }, () => Array<number>(24).fill(0))
+    for (const row of heatmapRows) {
+      heatmap[row.weekday][row.hour] = row.commits
+    }
+
     const repos = (await d1Query(
       c,
-      `SELECT repo_name, commits, bar_width FROM repo_details WHERE weekly_stats_id = ${id} ORDER BY commits DESC`
-    )) as Array<{ repo_name: string; commits: number; bar_width: number }>
+      `SELECT repo_name, commits, bar_width, additions, deletions, private, primary_language, url, pushed_at, languages FROM repo_details WHERE weekly_stats_id = ${id} ORDER BY commits DESC`
+    )) as Array<{
+      repo_name: string
+      commits: number
+      bar_width: number
+      additions: number
+      deletions: number
+      private: number
+      primary_language: string
+      url: string
+      pushed_at: string
+      languages: string
+    }>
 
     const languages = (await d1Query(
       c,
-      `SELECT language, commits, is_main FROM language_commits WHERE weekly_stats_id = ${id} ORDER BY commits DESC`
-    )) as Array<{ language: string; commits: number; is_main: number }>
+      `SELECT language, commits, is_main, lines, commit_count FROM language_commits WHERE weekly_stats_id = ${id} ORDER BY commits DESC`
+    )) as Array<{ language: string; commits: number; is_main: number; lines: number; commit_count: number }>
+
+    const commitTypes = (await d1Query(
+      c,
+      `SELECT commit_type, SUM(commits) AS commits FROM commit_types WHERE weekly_stats_id = ${id} GROUP BY commit_type ORDER BY commits DESC`
+    )) as Array<{ commit_type: string; commits: number }>
+
+    const goals = (await d1Query(
+      c,
+      `SELECT metric, target, actual, achieved FROM goal_results WHERE weekly_stats_id = ${id} ORDER BY id ASC`
+    )) as Array<{ metric: string; target: number; actual: number; achieved: number }>
 
     return c.json({
       summary: {
@@ -159,16 +200,38 @@ app.get('/api/weekly/:id', async (c) => {
         labels: hourly.map((row) => String(row.hour)),
         data: hourly.map((row) => row.commits)
       },
+      heatmap: {
+        data: heatmap
+      },
       repos: {
         labels: repos.map((row) => row.repo_name),
         data: repos.map((row) => row.commits),
-        barWidth: repos.map((row) => row.bar_width)
+        barWidth: repos.map((row) => row.bar_width),
+        additions: repos.map((row) => row.additions),
+        deletions: repos.map((row) => row.deletions),
+        private: repos.map((row) => Boolean(row.private)),
+        primaryLanguage: repos.map((row) => row.primary_language),
+        url: repos.map((row) => row.url),
+        pushedAt: repos.map((row) => row.pushed_at),
+        languages: repos.map((row) => JSON.parse(row.languages || '{}') as Record<string, number>)
       },
       languages: {
         labels: languages.map((row) => row.language),
         data: languages.map((row) => row.commits),
+        lines: languages.map((row) => row.lines),
+        commits: languages.map((row) => row.commit_count),
         isMain: languages.map((row) => Boolean(row.is_main))
-      }
+      },
+      commitTypes: {
+        labels: commitTypes.map((row) => row.commit_type),
+        data: commitTypes.map((row) => row.commits)
+      },
+      goals: goals.map((row) => ({
+        metric: row.metric,
+        target: row.target,
+        actual: row.actual,
+        achieved: Boolean(row.achieved)
+      }))
     })
   } catch (error) {
     const message = error instanceof Error ? error.message : 'Unexpected error'